@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"embed"
 	"flag"
 	"fmt"
@@ -9,9 +10,13 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	_ "time/tzdata" // Interview time zones on hosts without zoneinfo
 
 	"github.com/cv-forge/cv-forge/internal/api"
+	"github.com/cv-forge/cv-forge/internal/blob"
 	"github.com/cv-forge/cv-forge/internal/db"
+	"github.com/cv-forge/cv-forge/internal/fx"
+	"github.com/cv-forge/cv-forge/internal/notify"
 	"github.com/joho/godotenv"
 )
 
@@ -49,6 +54,30 @@ func main() {
 	}
 	defer database.Close()
 
+	// Binary content (photos, attachments) lives next to the database
+	blobDir := filepath.Join(filepath.Dir(*dbPath), "blobs")
+	blobs, err := blob.NewFSStore(blobDir)
+	if err != nil {
+		log.Fatalf("failed to open blob store: %v", err)
+	}
+
+	// Email notifications run in the background when SMTP is configured
+	mailer, err := notify.NewSMTPMailerFromEnv()
+	if err != nil {
+		log.Fatalf("failed to configure email: %v", err)
+	}
+	if mailer != nil {
+		scheduler := notify.NewScheduler(database, mailer)
+		scheduler.AppURL = os.Getenv("APP_URL")
+		go scheduler.Run(context.Background())
+	}
+
+	// Offer comparison converts currencies with a local rate table
+	rates, err := fx.RatesFromEnv()
+	if err != nil {
+		log.Fatalf("failed to read EXCHANGE_RATES: %v", err)
+	}
+
 	// Setup static file serving from embedded FS
 	distContent, err := fs.Sub(distFS, "dist")
 	if err != nil {
@@ -57,11 +86,17 @@ func main() {
 	staticFS := http.FS(distContent)
 
 	// Create router and start server
-	router := api.NewRouter(database, staticFS)
+	router := api.NewRouter(database, blobs, rates, staticFS)
 
 	addr := fmt.Sprintf(":%d", *port)
 	log.Printf("CV Forge starting on http://localhost%s", addr)
 	log.Printf("Database: %s", *dbPath)
+	log.Printf("Blob store: %s", blobDir)
+	if mailer != nil {
+		log.Printf("Email notifications via %s:%s", mailer.Host, mailer.Port)
+	} else {
+		log.Printf("Email notifications disabled (SMTP_HOST not set)")
+	}
 
 	if err := http.ListenAndServe(addr, router); err != nil {
 		log.Fatalf("server error: %v", err)
@@ -7,6 +7,7 @@ require (
 	github.com/golang-jwt/jwt/v5 v5.3.1
 	github.com/google/uuid v1.6.0
 	github.com/joho/godotenv v1.5.1
+	golang.org/x/net v0.46.0
 	golang.org/x/oauth2 v0.35.0
 	modernc.org/sqlite v1.45.0
 )
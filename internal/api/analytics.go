@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// applicationAnalytics reports on the user's job search. The optional
+// staleDays query parameter overrides how long an open application may go
+// without activity before it counts as stale.
+func (h *handler) applicationAnalytics(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	staleAfter := models.DefaultStaleAfter
+	if v := r.URL.Query().Get("staleDays"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 1 {
+			writeError(w, http.StatusBadRequest, "staleDays must be a positive number of days")
+			return
+		}
+		staleAfter = time.Duration(days) * 24 * time.Hour
+	}
+
+	analytics, err := h.db.ApplicationAnalytics(userID, time.Now(), staleAfter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to compute analytics")
+		return
+	}
+	writeJSON(w, http.StatusOK, analytics)
+}
+
+// cvEffectiveness reports which CVs and CV versions lead to interviews and
+// offers.
+func (h *handler) cvEffectiveness(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	report, err := h.db.CVEffectiveness(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to compute CV effectiveness")
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
@@ -2,12 +2,18 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
 
+	"github.com/cv-forge/cv-forge/internal/db"
 	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/validation"
 	"github.com/go-chi/chi/v5"
 )
 
+// listApplications lists the user's applications, filtered, ordered and
+// paged by the query parameters read by applicationFilter.
 func (h *handler) listApplications(w http.ResponseWriter, r *http.Request) {
 	userID, ok := GetUserID(r.Context())
 	if !ok {
@@ -15,12 +21,21 @@ func (h *handler) listApplications(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	apps, err := h.db.ListApplications(userID)
+	filter, err := applicationFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	apps, next, err := h.db.QueryApplications(userID, filter)
+	if errors.Is(err, db.ErrInvalidList) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to list applications")
 		return
 	}
-	writeJSON(w, http.StatusOK, apps)
+	writeList(w, apps, next)
 }
 
 func (h *handler) getApplication(w http.ResponseWriter, r *http.Request) {
@@ -40,6 +55,11 @@ func (h *handler) getApplication(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusNotFound, "application not found")
 		return
 	}
+	app.Attachments, err = h.db.ListAttachments(id, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list attachments")
+		return
+	}
 	writeJSON(w, http.StatusOK, app)
 }
 
@@ -55,10 +75,24 @@ func (h *handler) createApplication(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
+	// Older clients send a free-text salary instead
+	if req.Compensation == nil {
+		req.Compensation = models.ParseSalary(req.Salary)
+	}
 
-	// Basic validation
-	if req.Company == "" || req.Role == "" || req.Status == "" {
-		writeError(w, http.StatusBadRequest, "company, role, and status are required")
+	if !h.lookupApplicationCompany(w, userID, req.CompanyID, &req.Company) {
+		return
+	}
+	stages, err := h.db.GetPipeline(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get pipeline")
+		return
+	}
+	if errs := validation.CreateApplication(req, stages); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+	if !h.resolveApplicationCompany(w, userID, &req.CompanyID, &req.Company) {
 		return
 	}
 
@@ -78,11 +112,56 @@ func (h *handler) updateApplication(w http.ResponseWriter, r *http.Request) {
 	}
 
 	id := chi.URLParam(r, "id")
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
 	var req models.UpdateApplicationRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	var sent map[string]json.RawMessage
+	if json.Unmarshal(body, &req) != nil || json.Unmarshal(body, &sent) != nil {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
+	// Clients that predate the deadline or follow-up date leave them out;
+	// keep them rather than clearing them
+	_, hasDeadline := sent["deadline"]
+	_, hasFollowUp := sent["followUpAt"]
+	if !hasDeadline || !hasFollowUp {
+		existing, err := h.db.GetApplication(id, userID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to get application")
+			return
+		}
+		if existing == nil {
+			writeError(w, http.StatusNotFound, "application not found")
+			return
+		}
+		if !hasDeadline {
+			req.Deadline = existing.Deadline
+		}
+		if !hasFollowUp {
+			req.FollowUpAt = existing.FollowUpAt
+		}
+	}
+	if req.Compensation == nil {
+		req.Compensation = models.ParseSalary(req.Salary)
+	}
+	if !h.lookupApplicationCompany(w, userID, req.CompanyID, &req.Company) {
+		return
+	}
+	stages, err := h.db.GetPipeline(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get pipeline")
+		return
+	}
+	if errs := validation.UpdateApplication(req, stages); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+	if !h.resolveApplicationCompany(w, userID, &req.CompanyID, &req.Company) {
+		return
+	}
 
 	app, err := h.db.UpdateApplication(id, userID, req)
 	if err != nil {
@@ -104,7 +183,12 @@ func (h *handler) deleteApplication(w http.ResponseWriter, r *http.Request) {
 	}
 
 	id := chi.URLParam(r, "id")
-	ok, err := h.db.DeleteApplication(id, userID)
+	atts, err := h.db.ListAttachments(id, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list attachments")
+		return
+	}
+	ok, err = h.db.DeleteApplication(id, userID)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to delete application")
 		return
@@ -113,5 +197,11 @@ func (h *handler) deleteApplication(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusNotFound, "application not found")
 		return
 	}
+	// Attachment rows are gone with the application; drop their blobs too
+	for _, att := range atts {
+		if err := h.removeBlob(att.BlobID, userID); err != nil {
+			log.Printf("delete attachment blob %s: %v", att.BlobID, err)
+		}
+	}
 	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
 }
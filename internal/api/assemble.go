@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/cv-forge/cv-forge/internal/assemble"
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/validation"
+	"github.com/go-chi/chi/v5"
+)
+
+// assembleCV builds a tailored CV from the tagged content of a CV, either
+// as a new CV or as a new version of the source CV.
+func (h *handler) assembleCV(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.AssembleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if errs := validation.Assemble(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	cv, err := h.db.GetCV(id, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get CV")
+		return
+	}
+	if cv == nil {
+		writeError(w, http.StatusNotFound, "CV not found")
+		return
+	}
+
+	data, report := assemble.Assemble(cv.Data, assemble.Options{
+		Tags:               req.Tags,
+		MaxPages:           req.MaxPages,
+		MaxEntries:         req.MaxEntries,
+		MaxBulletsPerEntry: req.MaxBulletsPerEntry,
+		KeepUntagged:       req.KeepUntagged,
+	})
+	resp := models.AssembleResponse{Report: report}
+
+	if req.Target == "version" {
+		if req.Message == "" {
+			req.Message = "Assembled for " + strings.Join(req.Tags, ", ")
+		}
+		resp.Version, err = h.db.CreateVersionFromData(id, req.Message, data)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to create version")
+			return
+		}
+		writeJSON(w, http.StatusCreated, resp)
+		return
+	}
+
+	if req.Title == "" {
+		req.Title = cv.Title + " (" + strings.Join(req.Tags, ", ") + ")"
+	}
+	resp.CV, err = h.db.CreateCV(userID, req.Title, data)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create CV")
+		return
+	}
+	writeJSON(w, http.StatusCreated, resp)
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxAttachmentSize is the upload limit for application attachments.
+const maxAttachmentSize = 20 << 20
+
+// attachmentTypes are the sniffed content types accepted as attachments.
+// Nothing a browser would run, such as HTML, is accepted.
+var attachmentTypes = map[string]bool{
+	"application/pdf": true,
+	"text/plain":      true,
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":       true,
+}
+
+// --- Attachment handlers ---
+
+func (h *handler) listAttachments(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	appID := chi.URLParam(r, "id")
+	app, err := h.db.GetApplication(appID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get application")
+		return
+	}
+	if app == nil {
+		writeError(w, http.StatusNotFound, "application not found")
+		return
+	}
+
+	atts, err := h.db.ListAttachments(appID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list attachments")
+		return
+	}
+	writeJSON(w, http.StatusOK, atts)
+}
+
+func (h *handler) uploadAttachment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	kind := models.AttachmentKind(r.URL.Query().Get("kind"))
+	if kind == "" {
+		kind = models.AttachmentOther
+	}
+	if !kind.Valid() {
+		writeError(w, http.StatusBadRequest, "invalid attachment kind")
+		return
+	}
+
+	appID := chi.URLParam(r, "id")
+	app, err := h.db.GetApplication(appID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get application")
+		return
+	}
+	if app == nil {
+		writeError(w, http.StatusNotFound, "application not found")
+		return
+	}
+
+	b, err := h.storeUpload(w, r, userID, maxAttachmentSize, attachmentTypes)
+	if err != nil {
+		writeUploadError(w, err)
+		return
+	}
+
+	att, err := h.db.CreateAttachment(appID, kind, *b)
+	if err != nil {
+		h.removeBlob(b.ID, userID)
+		writeError(w, http.StatusInternalServerError, "failed to create attachment")
+		return
+	}
+	writeJSON(w, http.StatusCreated, att)
+}
+
+func (h *handler) downloadAttachment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	att, err := h.db.GetAttachment(chi.URLParam(r, "id"), chi.URLParam(r, "attachmentId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get attachment")
+		return
+	}
+	if att == nil {
+		writeError(w, http.StatusNotFound, "attachment not found")
+		return
+	}
+	h.serveBlob(w, &models.Blob{
+		ID:          att.BlobID,
+		Filename:    att.Filename,
+		ContentType: att.ContentType,
+		Size:        att.Size,
+	}, true)
+}
+
+func (h *handler) deleteAttachment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	att, err := h.db.GetAttachment(chi.URLParam(r, "id"), chi.URLParam(r, "attachmentId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get attachment")
+		return
+	}
+	if att == nil {
+		writeError(w, http.StatusNotFound, "attachment not found")
+		return
+	}
+
+	// Deleting the blob cascades to the attachment row
+	if err := h.removeBlob(att.BlobID, userID); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to delete attachment")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// removeBlob deletes a blob's metadata and content.
+func (h *handler) removeBlob(id, userID string) error {
+	if _, err := h.db.DeleteBlob(id, userID); err != nil {
+		return err
+	}
+	if err := h.blobs.Delete(id); err != nil {
+		log.Printf("delete blob %s: %v", id, err)
+	}
+	return nil
+}
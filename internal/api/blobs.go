@@ -0,0 +1,260 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/blob"
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// maxPhotoSize is the upload limit for /api/blobs.
+const maxPhotoSize = 5 << 20
+
+// photoTypes are the sniffed content types accepted by /api/blobs.
+var photoTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+var (
+	errUploadTooLarge  = errors.New("file too large")
+	errUploadType      = errors.New("unsupported file type")
+	errUploadMissing   = errors.New("missing file")
+	errUploadMalformed = errors.New("malformed upload")
+	errUploadFailed    = errors.New("failed to store file")
+)
+
+// --- Blob handlers ---
+
+func (h *handler) uploadBlob(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	b, err := h.storeUpload(w, r, userID, maxPhotoSize, photoTypes)
+	if err != nil {
+		writeUploadError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, b)
+}
+
+func (h *handler) getBlob(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	b, err := h.db.GetBlob(chi.URLParam(r, "blobId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get blob")
+		return
+	}
+	// Only photos are shown inline; attachments are downloaded through
+	// their application
+	if b == nil || !photoTypes[b.ContentType] {
+		writeError(w, http.StatusNotFound, "blob not found")
+		return
+	}
+	h.serveBlob(w, b, false)
+}
+
+func (h *handler) deleteBlob(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "blobId")
+	b, err := h.db.GetBlob(id, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get blob")
+		return
+	}
+	if b == nil {
+		writeError(w, http.StatusNotFound, "blob not found")
+		return
+	}
+	if err := h.removeBlob(id, userID); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to delete blob")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// --- Upload helpers ---
+
+// storeUpload reads a single file from the request, either as the "file"
+// field of a multipart form or as the raw body, sniffs its content type and
+// writes it to the blob store. The content type sent by the client is ignored.
+func (h *handler) storeUpload(w http.ResponseWriter, r *http.Request, userID string, limit int64, allowed map[string]bool) (*models.Blob, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, limit+(64<<10)) // slack for multipart framing
+
+	var src io.Reader
+	filename := r.URL.Query().Get("filename")
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt == "multipart/form-data" {
+		mr, err := r.MultipartReader()
+		if err != nil {
+			return nil, errUploadMalformed
+		}
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				return nil, errUploadMissing
+			}
+			if err != nil {
+				return nil, uploadReadError(err)
+			}
+			if part.FormName() == "file" {
+				src = part
+				if part.FileName() != "" {
+					filename = part.FileName()
+				}
+				break
+			}
+		}
+	} else {
+		src = r.Body
+	}
+
+	// Sniff the content type from the first bytes
+	head := make([]byte, 512)
+	n, err := io.ReadFull(src, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, uploadReadError(err)
+	}
+	if n == 0 {
+		return nil, errUploadMissing
+	}
+	contentType := sniffContentType(head[:n], filename)
+	if !allowed[contentType] {
+		return nil, fmt.Errorf("%w: %s", errUploadType, contentType)
+	}
+
+	return h.putBlob(userID, filename, contentType, io.MultiReader(bytes.NewReader(head[:n]), src), limit)
+}
+
+// putBlob writes content to the blob store and records its metadata.
+func (h *handler) putBlob(userID, filename, contentType string, content io.Reader, limit int64) (*models.Blob, error) {
+	id := uuid.New().String()
+	size, err := h.blobs.Put(id, io.LimitReader(content, limit+1))
+	if err != nil {
+		h.blobs.Delete(id)
+		return nil, uploadReadError(err)
+	}
+	if size > limit {
+		h.blobs.Delete(id)
+		return nil, errUploadTooLarge
+	}
+
+	b := models.Blob{
+		ID:          id,
+		Filename:    filepath.Base(filename),
+		ContentType: contentType,
+		Size:        size,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if b.Filename == "." || b.Filename == string(filepath.Separator) {
+		b.Filename = ""
+	}
+	if err := h.db.CreateBlob(userID, b); err != nil {
+		h.blobs.Delete(id)
+		return nil, errUploadFailed
+	}
+	return &b, nil
+}
+
+// sniffContentType detects the content type of an upload. Office documents
+// sniff as plain zip archives, so those are refined by file extension.
+func sniffContentType(head []byte, filename string) string {
+	ct, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if ct == "application/zip" {
+		switch strings.ToLower(filepath.Ext(filename)) {
+		case ".docx":
+			return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+		case ".xlsx":
+			return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		}
+	}
+	return ct
+}
+
+func uploadReadError(err error) error {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return errUploadTooLarge
+	}
+	return errUploadMalformed
+}
+
+func writeUploadError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errUploadTooLarge):
+		writeError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, errUploadType):
+		writeError(w, http.StatusUnsupportedMediaType, err.Error())
+	case errors.Is(err, errUploadFailed):
+		writeError(w, http.StatusInternalServerError, err.Error())
+	default:
+		writeError(w, http.StatusBadRequest, err.Error())
+	}
+}
+
+// serveBlob streams a blob's content. Attachments are served with a
+// download disposition; images are shown inline. Either way the content is
+// sandboxed, so that a file stored before its type was refused cannot run
+// script on this origin.
+func (h *handler) serveBlob(w http.ResponseWriter, b *models.Blob, download bool) {
+	rc, err := h.blobs.Get(b.ID)
+	if errors.Is(err, blob.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "blob not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to read blob")
+		return
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", b.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(b.Size, 10))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "sandbox")
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	if download {
+		filename := b.Filename
+		if filename == "" {
+			filename = b.ID
+		}
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	}
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, rc)
+}
+
+// readBlob loads a blob's content into memory, for embedding in exports.
+func (h *handler) readBlob(b *models.Blob) ([]byte, error) {
+	rc, err := h.blobs.Get(b.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
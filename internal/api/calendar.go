@@ -0,0 +1,200 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/ical"
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// feedHistory is how far back the calendar feed reaches, so recent events
+// do not vanish from calendars the moment they are over.
+const feedHistory = 30 * 24 * time.Hour
+
+// --- Calendar feed handlers ---
+
+func (h *handler) getCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	createdAt, err := h.db.GetCalendarTokenCreated(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get calendar feed")
+		return
+	}
+	writeJSON(w, http.StatusOK, models.CalendarFeed{Enabled: createdAt != nil, CreatedAt: createdAt})
+}
+
+// rotateCalendarFeed issues a new feed token, enabling the feed or
+// invalidating the previous URL.
+func (h *handler) rotateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create calendar feed")
+		return
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	createdAt, err := h.db.SetCalendarToken(userID, hashCalendarToken(token))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create calendar feed")
+		return
+	}
+	writeJSON(w, http.StatusOK, models.CalendarFeed{
+		Enabled:   true,
+		URL:       fmt.Sprintf("%s/calendar/%s.ics", requestOrigin(r), token),
+		CreatedAt: &createdAt,
+	})
+}
+
+func (h *handler) deleteCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	deleted, err := h.db.DeleteCalendarToken(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to delete calendar feed")
+		return
+	}
+	if !deleted {
+		writeError(w, http.StatusNotFound, "calendar feed not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// calendarFeed serves the subscription feed. It sits outside AuthMiddleware
+// since calendar clients cannot send the session cookie; the token in the
+// URL is the credential.
+func (h *handler) calendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.db.UserIDByCalendarToken(hashCalendarToken(chi.URLParam(r, "token")))
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if userID == "" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	cal, err := h.feedCalendar(userID)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, max-age=900")
+	w.WriteHeader(http.StatusOK)
+	if err := cal.Write(w); err != nil {
+		log.Printf("write calendar feed: %v", err)
+	}
+}
+
+// feedCalendar collects the events of a user's calendar feed: interviews,
+// and the deadlines and follow-up dates of applications still in an open
+// stage.
+func (h *handler) feedCalendar(userID string) (ical.Calendar, error) {
+	cal := ical.Calendar{Name: "CV Forge"}
+	now := time.Now()
+
+	interviews, err := h.db.ListUpcomingInterviews(userID, now.Add(-feedHistory))
+	if err != nil {
+		return cal, err
+	}
+	for _, iv := range interviews {
+		cal.Events = append(cal.Events, interviewEvent(iv))
+	}
+
+	apps, err := h.db.ListApplications(userID)
+	if err != nil {
+		return cal, err
+	}
+	stages, err := h.db.GetPipeline(userID)
+	if err != nil {
+		return cal, err
+	}
+	for _, app := range apps {
+		if stage := models.FindStage(stages, app.Status); stage != nil && stage.Terminal {
+			continue
+		}
+		if app.Deadline != nil && app.Deadline.After(now.Add(-feedHistory)) {
+			cal.Events = append(cal.Events, ical.Event{
+				UID:         app.ID + "-deadline@cv-forge",
+				Start:       *app.Deadline,
+				AllDay:      true,
+				Summary:     fmt.Sprintf("Deadline: %s at %s", app.Role, app.Company),
+				Description: app.URL,
+				URL:         app.URL,
+				Updated:     app.UpdatedAt,
+			})
+		}
+		if app.FollowUpAt != nil && app.FollowUpAt.After(now.Add(-feedHistory)) {
+			cal.Events = append(cal.Events, ical.Event{
+				UID:         app.ID + "-followup@cv-forge",
+				Start:       *app.FollowUpAt,
+				AllDay:      true,
+				Summary:     fmt.Sprintf("Follow up: %s at %s", app.Role, app.Company),
+				Description: app.URL,
+				URL:         app.URL,
+				Updated:     app.UpdatedAt,
+			})
+		}
+	}
+	return cal, nil
+}
+
+// redactFeedToken keeps calendar feed tokens out of the request log. The
+// logger prints RequestURI while routing uses URL.Path, so only the former
+// is rewritten.
+func redactFeedToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/calendar/") {
+			r2 := *r
+			r2.RequestURI = "/calendar/[redacted].ics"
+			r = &r2
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// hashCalendarToken returns the form feed tokens are stored in, so a
+// leaked database does not expose working feed URLs.
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// requestOrigin returns the scheme and host the client used to reach the
+// server, honouring a TLS-terminating proxy.
+func requestOrigin(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = strings.TrimSpace(strings.Split(proto, ",")[0])
+	}
+	return scheme + "://" + r.Host
+}
@@ -0,0 +1,222 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/cv-forge/cv-forge/internal/db"
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/validation"
+	"github.com/go-chi/chi/v5"
+)
+
+// --- Company handlers ---
+
+// listCompanies returns the user's companies, optionally those matching
+// the q query parameter by name, alias or website.
+func (h *handler) listCompanies(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	companies, err := h.db.ListCompanies(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list companies")
+		return
+	}
+	if q := models.CompanyKey(r.URL.Query().Get("q")); q != "" {
+		matched := []models.Company{}
+		for _, c := range companies {
+			for _, k := range c.Keys() {
+				if strings.Contains(k, q) {
+					matched = append(matched, c)
+					break
+				}
+			}
+		}
+		companies = matched
+	}
+	writeJSON(w, http.StatusOK, companies)
+}
+
+func (h *handler) getCompany(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	company, err := h.db.GetCompany(chi.URLParam(r, "companyId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get company")
+		return
+	}
+	if company == nil {
+		writeError(w, http.StatusNotFound, "company not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, company)
+}
+
+func (h *handler) createCompany(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.CompanyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if errs := validation.Company(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	company, err := h.db.CreateCompany(userID, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create company")
+		return
+	}
+	writeJSON(w, http.StatusCreated, company)
+}
+
+func (h *handler) updateCompany(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.CompanyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if errs := validation.Company(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	company, err := h.db.UpdateCompany(chi.URLParam(r, "companyId"), userID, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update company")
+		return
+	}
+	if company == nil {
+		writeError(w, http.StatusNotFound, "company not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, company)
+}
+
+func (h *handler) deleteCompany(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	deleted, err := h.db.DeleteCompany(chi.URLParam(r, "companyId"), userID)
+	if errors.Is(err, db.ErrCompanyInUse) {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to delete company")
+		return
+	}
+	if !deleted {
+		writeError(w, http.StatusNotFound, "company not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// listDuplicateCompanies suggests groups of companies to merge.
+func (h *handler) listDuplicateCompanies(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	companies, err := h.db.ListCompanies(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list companies")
+		return
+	}
+	writeJSON(w, http.StatusOK, models.FindDuplicateCompanies(companies))
+}
+
+func (h *handler) mergeCompanies(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.MergeCompaniesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if errs := validation.MergeCompanies(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	company, err := h.db.MergeCompanies(userID, req.TargetID, req.SourceIDs)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to merge companies")
+		return
+	}
+	if company == nil {
+		writeError(w, http.StatusNotFound, "company not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, company)
+}
+
+// lookupApplicationCompany fills in the company name of an application
+// payload that names its company by ID, so it validates like one that
+// names it directly. It reports false after writing an error response.
+func (h *handler) lookupApplicationCompany(w http.ResponseWriter, userID string, companyID *string, company *string) bool {
+	if companyID == nil {
+		return true
+	}
+	c, err := h.db.GetCompany(*companyID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get company")
+		return false
+	}
+	if c == nil {
+		writeValidationError(w, validation.Errors{{Path: "/companyId", Message: "company not found"}})
+		return false
+	}
+	*company = c.Name
+	return true
+}
+
+// resolveApplicationCompany links an application payload without a
+// company ID to the company matching its company name, creating one if
+// needed. It reports false after writing an error response.
+func (h *handler) resolveApplicationCompany(w http.ResponseWriter, userID string, companyID **string, company *string) bool {
+	if *companyID != nil {
+		return true
+	}
+	c, err := h.db.ResolveCompany(userID, *company)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to resolve company")
+		return false
+	}
+	*companyID = &c.ID
+	*company = c.Name
+	return true
+}
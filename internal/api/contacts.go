@@ -0,0 +1,319 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/validation"
+	"github.com/go-chi/chi/v5"
+)
+
+// --- Contact handlers ---
+
+// listContacts searches contacts with the query parameters q, company,
+// applicationId, and from/to bounding the date of an interaction
+// (YYYY-MM-DD or RFC 3339; to is exclusive).
+func (h *handler) listContacts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	q := r.URL.Query()
+	search := models.ContactSearch{
+		Query:         q.Get("q"),
+		Company:       q.Get("company"),
+		ApplicationID: q.Get("applicationId"),
+	}
+	var err error
+	if search.From, err = parseTimeParam(q.Get("from")); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid from date")
+		return
+	}
+	if search.To, err = parseTimeParam(q.Get("to")); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid to date")
+		return
+	}
+
+	contacts, err := h.db.SearchContacts(userID, search)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list contacts")
+		return
+	}
+	writeJSON(w, http.StatusOK, contacts)
+}
+
+func (h *handler) getContact(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	contact, err := h.db.GetContact(chi.URLParam(r, "contactId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get contact")
+		return
+	}
+	if contact == nil {
+		writeError(w, http.StatusNotFound, "contact not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, contact)
+}
+
+func (h *handler) createContact(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.ContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if errs := validation.Contact(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	contact, err := h.db.CreateContact(userID, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create contact")
+		return
+	}
+	writeJSON(w, http.StatusCreated, contact)
+}
+
+func (h *handler) updateContact(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.ContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if errs := validation.Contact(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	contact, err := h.db.UpdateContact(chi.URLParam(r, "contactId"), userID, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update contact")
+		return
+	}
+	if contact == nil {
+		writeError(w, http.StatusNotFound, "contact not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, contact)
+}
+
+func (h *handler) deleteContact(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	deleted, err := h.db.DeleteContact(chi.URLParam(r, "contactId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to delete contact")
+		return
+	}
+	if !deleted {
+		writeError(w, http.StatusNotFound, "contact not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+func (h *handler) createInteraction(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.InteractionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if errs := validation.Interaction(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	contactID := chi.URLParam(r, "contactId")
+	contact, err := h.db.GetContact(contactID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get contact")
+		return
+	}
+	if contact == nil {
+		writeError(w, http.StatusNotFound, "contact not found")
+		return
+	}
+	if req.ApplicationID != nil {
+		app, err := h.db.GetApplication(*req.ApplicationID, userID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to get application")
+			return
+		}
+		if app == nil {
+			writeValidationError(w, validation.Errors{{Path: "/applicationId", Message: "application not found"}})
+			return
+		}
+	}
+
+	interaction, err := h.db.CreateInteraction(contactID, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create interaction")
+		return
+	}
+	writeJSON(w, http.StatusCreated, interaction)
+}
+
+func (h *handler) deleteInteraction(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	deleted, err := h.db.DeleteInteraction(chi.URLParam(r, "contactId"), chi.URLParam(r, "interactionId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to delete interaction")
+		return
+	}
+	if !deleted {
+		writeError(w, http.StatusNotFound, "interaction not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// --- Application contact handlers ---
+
+func (h *handler) listApplicationContacts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	appID := chi.URLParam(r, "id")
+	app, err := h.db.GetApplication(appID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get application")
+		return
+	}
+	if app == nil {
+		writeError(w, http.StatusNotFound, "application not found")
+		return
+	}
+
+	links, err := h.db.ListApplicationContacts(appID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list contacts")
+		return
+	}
+	writeJSON(w, http.StatusOK, links)
+}
+
+func (h *handler) linkContact(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.LinkContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Relationship == "" {
+		req.Relationship = models.RelationshipOther
+	}
+	if errs := validation.LinkContact(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	appID, contactID := chi.URLParam(r, "id"), chi.URLParam(r, "contactId")
+	app, err := h.db.GetApplication(appID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get application")
+		return
+	}
+	if app == nil {
+		writeError(w, http.StatusNotFound, "application not found")
+		return
+	}
+	contact, err := h.db.GetContact(contactID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get contact")
+		return
+	}
+	if contact == nil {
+		writeError(w, http.StatusNotFound, "contact not found")
+		return
+	}
+
+	if err := h.db.LinkContact(appID, contactID, req.Relationship); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to link contact")
+		return
+	}
+	links, err := h.db.ListApplicationContacts(appID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list contacts")
+		return
+	}
+	writeJSON(w, http.StatusOK, links)
+}
+
+func (h *handler) unlinkContact(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	deleted, err := h.db.UnlinkContact(chi.URLParam(r, "id"), chi.URLParam(r, "contactId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to unlink contact")
+		return
+	}
+	if !deleted {
+		writeError(w, http.StatusNotFound, "contact not linked")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// parseTimeParam parses an optional date or timestamp query parameter.
+func parseTimeParam(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		if t, err = time.Parse(time.RFC3339, s); err != nil {
+			return nil, err
+		}
+	}
+	t = t.UTC()
+	return &t, nil
+}
@@ -0,0 +1,528 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/coverletter"
+	"github.com/cv-forge/cv-forge/internal/document"
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/validation"
+	"github.com/go-chi/chi/v5"
+)
+
+// --- Cover letter template handlers ---
+
+func (h *handler) listCoverLetterTemplates(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	templates, err := h.db.ListCoverLetterTemplates(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list cover letter templates")
+		return
+	}
+	writeJSON(w, http.StatusOK, templates)
+}
+
+func (h *handler) getCoverLetterTemplate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	t, err := h.db.GetCoverLetterTemplate(chi.URLParam(r, "templateId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get cover letter template")
+		return
+	}
+	if t == nil {
+		writeError(w, http.StatusNotFound, "cover letter template not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (h *handler) createCoverLetterTemplate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	req, ok := decodeCoverLetterTemplate(w, r)
+	if !ok {
+		return
+	}
+	t, err := h.db.CreateCoverLetterTemplate(userID, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create cover letter template")
+		return
+	}
+	writeJSON(w, http.StatusCreated, t)
+}
+
+func (h *handler) updateCoverLetterTemplate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	req, ok := decodeCoverLetterTemplate(w, r)
+	if !ok {
+		return
+	}
+	t, err := h.db.UpdateCoverLetterTemplate(chi.URLParam(r, "templateId"), userID, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update cover letter template")
+		return
+	}
+	if t == nil {
+		writeError(w, http.StatusNotFound, "cover letter template not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (h *handler) deleteCoverLetterTemplate(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	deleted, err := h.db.DeleteCoverLetterTemplate(chi.URLParam(r, "templateId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to delete cover letter template")
+		return
+	}
+	if !deleted {
+		writeError(w, http.StatusNotFound, "cover letter template not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeCoverLetterTemplate reads and validates a template payload,
+// writing the error response itself when it fails.
+func decodeCoverLetterTemplate(w http.ResponseWriter, r *http.Request) (models.CoverLetterTemplateRequest, bool) {
+	var req models.CoverLetterTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return req, false
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if errs := validation.CoverLetterTemplate(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return req, false
+	}
+	return req, true
+}
+
+// --- Cover letter handlers ---
+
+func (h *handler) listCoverLetters(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	appID := chi.URLParam(r, "id")
+	app, err := h.db.GetApplication(appID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get application")
+		return
+	}
+	if app == nil {
+		writeError(w, http.StatusNotFound, "application not found")
+		return
+	}
+
+	letters, err := h.db.ListCoverLetters(appID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list cover letters")
+		return
+	}
+	writeJSON(w, http.StatusOK, letters)
+}
+
+func (h *handler) getCoverLetter(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	l, ok := h.findCoverLetter(w, r, userID)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, l)
+}
+
+// createCoverLetter adds a cover letter to an application, written out or
+// filled in from a template.
+func (h *handler) createCoverLetter(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.CreateCoverLetterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if errs := validation.CreateCoverLetter(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+	appID := chi.URLParam(r, "id")
+	app, ok := h.fillCoverLetter(w, appID, userID, &req)
+	if !ok {
+		return
+	}
+	if req.Title == "" {
+		req.Title = "Cover letter for " + app.Role + " at " + app.Company
+	}
+
+	l, err := h.db.CreateCoverLetter(appID, userID, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create cover letter")
+		return
+	}
+	if l == nil {
+		writeError(w, http.StatusNotFound, "application not found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, l)
+}
+
+// previewCoverLetter fills in a template for an application without
+// saving the result.
+func (h *handler) previewCoverLetter(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.CreateCoverLetterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.TemplateID == nil || *req.TemplateID == "" {
+		writeValidationError(w, validation.Errors{{Path: "/templateId", Message: "is required"}})
+		return
+	}
+	req.Body = ""
+	if _, ok := h.fillCoverLetter(w, chi.URLParam(r, "id"), userID, &req); !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, models.RenderedCoverLetter{Body: req.Body})
+}
+
+// fillCoverLetter resolves the CV a letter draws on, defaulting to the one
+// linked to the application, and fills in the body from the template when
+// none is given. It writes the error response itself when it fails.
+func (h *handler) fillCoverLetter(w http.ResponseWriter, appID, userID string, req *models.CreateCoverLetterRequest) (*models.Application, bool) {
+	app, err := h.db.GetApplication(appID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get application")
+		return nil, false
+	}
+	if app == nil {
+		writeError(w, http.StatusNotFound, "application not found")
+		return nil, false
+	}
+
+	if req.CVID == nil || *req.CVID == "" {
+		req.CVID, req.CVVersionID = app.CVID, app.CVVersionID
+	}
+	if req.CVVersionID != nil && *req.CVVersionID == "" {
+		req.CVVersionID = nil
+	}
+	var data *models.CVData
+	if req.CVID != nil {
+		cv, err := h.db.GetCV(*req.CVID, userID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to get CV")
+			return nil, false
+		}
+		if cv == nil {
+			writeError(w, http.StatusNotFound, "CV not found")
+			return nil, false
+		}
+		data = &cv.Data
+		if req.CVVersionID != nil {
+			v, err := h.db.GetVersion(cv.ID, *req.CVVersionID)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, "failed to get version")
+				return nil, false
+			}
+			if v == nil {
+				writeError(w, http.StatusNotFound, "version not found")
+				return nil, false
+			}
+			data = &v.Data
+		}
+	}
+
+	if req.TemplateID != nil && *req.TemplateID == "" {
+		req.TemplateID = nil
+	}
+	if req.TemplateID == nil || strings.TrimSpace(req.Body) != "" {
+		return app, true
+	}
+	t, err := h.db.GetCoverLetterTemplate(*req.TemplateID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get cover letter template")
+		return nil, false
+	}
+	if t == nil {
+		writeError(w, http.StatusNotFound, "cover letter template not found")
+		return nil, false
+	}
+	req.Body, err = coverletter.Render(t.Body, coverletter.NewFields(*app, data, time.Now()))
+	if err != nil {
+		writeValidationError(w, validation.Errors{{Path: "/templateId", Message: "template cannot be filled in: " + err.Error()}})
+		return nil, false
+	}
+	return app, true
+}
+
+func (h *handler) updateCoverLetter(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.UpdateCoverLetterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if errs := validation.UpdateCoverLetter(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	l, err := h.db.UpdateCoverLetter(chi.URLParam(r, "id"), chi.URLParam(r, "letterId"), userID, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update cover letter")
+		return
+	}
+	if l == nil {
+		writeError(w, http.StatusNotFound, "cover letter not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, l)
+}
+
+func (h *handler) deleteCoverLetter(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	deleted, err := h.db.DeleteCoverLetter(chi.URLParam(r, "id"), chi.URLParam(r, "letterId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to delete cover letter")
+		return
+	}
+	if !deleted {
+		writeError(w, http.StatusNotFound, "cover letter not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// exportCoverLetter downloads a cover letter as PDF, DOCX or JSON. The
+// JSON export carries the letter's versions too.
+func (h *handler) exportCoverLetter(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	l, ok := h.findCoverLetter(w, r, userID)
+	if !ok {
+		return
+	}
+
+	format := chi.URLParam(r, "format")
+	doc := document.Document{Title: l.Title, Blocks: document.Paragraphs(l.Body)}
+	var buf bytes.Buffer
+	var contentType string
+	var err error
+	switch format {
+	case "pdf":
+		contentType = "application/pdf"
+		err = document.WritePDF(&buf, doc)
+	case "docx":
+		contentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+		err = document.WriteDOCX(&buf, doc)
+	case "json":
+		app, err := h.db.GetApplication(l.ApplicationID, userID)
+		if err != nil || app == nil {
+			writeError(w, http.StatusInternalServerError, "failed to get application")
+			return
+		}
+		versions, err := h.db.ListCoverLetterVersions(l.ID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to list versions")
+			return
+		}
+		contentType = "application/json"
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		enc.Encode(models.CoverLetterExport{
+			Title:      l.Title,
+			Body:       l.Body,
+			Company:    app.Company,
+			Role:       app.Role,
+			ExportedAt: time.Now().UTC(),
+			Versions:   versions,
+		})
+	default:
+		writeError(w, http.StatusNotFound, "unknown export format")
+		return
+	}
+	if errors.Is(err, document.ErrUnsupportedText) {
+		writeError(w, http.StatusUnprocessableEntity, err.Error()+"; export it as DOCX instead")
+		return
+	}
+	if err != nil {
+		log.Printf("export cover letter %s as %s: %v", l.ID, format, err)
+		writeError(w, http.StatusInternalServerError, "failed to export cover letter")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": l.Title + "." + format}))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// --- Cover letter version handlers ---
+
+func (h *handler) listCoverLetterVersions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	l, ok := h.findCoverLetter(w, r, userID)
+	if !ok {
+		return
+	}
+	versions, err := h.db.ListCoverLetterVersions(l.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list versions")
+		return
+	}
+	writeJSON(w, http.StatusOK, versions)
+}
+
+func (h *handler) getCoverLetterVersion(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	l, ok := h.findCoverLetter(w, r, userID)
+	if !ok {
+		return
+	}
+	v, err := h.db.GetCoverLetterVersion(l.ID, chi.URLParam(r, "vid"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get version")
+		return
+	}
+	if v == nil {
+		writeError(w, http.StatusNotFound, "version not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+func (h *handler) createCoverLetterVersion(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.CreateVersionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Message == "" {
+		req.Message = "Snapshot"
+	}
+
+	v, err := h.db.CreateCoverLetterVersion(chi.URLParam(r, "id"), chi.URLParam(r, "letterId"), userID, req.Message)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create version")
+		return
+	}
+	if v == nil {
+		writeError(w, http.StatusNotFound, "cover letter not found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, v)
+}
+
+func (h *handler) restoreCoverLetterVersion(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	l, err := h.db.RestoreCoverLetterVersion(chi.URLParam(r, "id"), chi.URLParam(r, "letterId"), chi.URLParam(r, "vid"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to restore version")
+		return
+	}
+	if l == nil {
+		writeError(w, http.StatusNotFound, "cover letter or version not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, l)
+}
+
+// findCoverLetter loads the cover letter named in the URL, writing the
+// error response itself when it fails.
+func (h *handler) findCoverLetter(w http.ResponseWriter, r *http.Request, userID string) (*models.CoverLetter, bool) {
+	l, err := h.db.GetCoverLetter(chi.URLParam(r, "id"), chi.URLParam(r, "letterId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get cover letter")
+		return nil, false
+	}
+	if l == nil {
+		writeError(w, http.StatusNotFound, "cover letter not found")
+		return nil, false
+	}
+	return l, true
+}
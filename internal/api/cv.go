@@ -2,19 +2,31 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"time"
 
+	"github.com/cv-forge/cv-forge/internal/blob"
 	"github.com/cv-forge/cv-forge/internal/db"
+	"github.com/cv-forge/cv-forge/internal/fx"
+	"github.com/cv-forge/cv-forge/internal/jobposting"
 	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/validation"
 	"github.com/go-chi/chi/v5"
 )
 
 type handler struct {
-	db *db.DB
+	db    *db.DB
+	blobs blob.Store
+	rates fx.Rates
+
+	postings *jobposting.Fetcher
 }
 
 // --- CV CRUD ---
 
+// listCVs lists the user's CVs, optionally searched by title with q, and
+// ordered and paged by the list options.
 func (h *handler) listCVs(w http.ResponseWriter, r *http.Request) {
 	userID, ok := GetUserID(r.Context())
 	if !ok {
@@ -22,12 +34,21 @@ func (h *handler) listCVs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cvs, err := h.db.ListCVs(userID)
+	opts, err := listOptions(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	cvs, next, err := h.db.QueryCVs(userID, models.CVFilter{ListOptions: opts, Query: r.URL.Query().Get("q")})
+	if errors.Is(err, db.ErrInvalidList) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to list CVs")
 		return
 	}
-	writeJSON(w, http.StatusOK, cvs)
+	writeList(w, cvs, next)
 }
 
 func (h *handler) getCV(w http.ResponseWriter, r *http.Request) {
@@ -47,6 +68,13 @@ func (h *handler) getCV(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusNotFound, "CV not found")
 		return
 	}
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		cv.Data = cv.Data.Localize(lang)
+	}
+	// Optionally compute skill years from the experience entries
+	if r.URL.Query().Get("deriveYears") == "true" {
+		models.DeriveSkillYears(&cv.Data, time.Now().UTC())
+	}
 	writeJSON(w, http.StatusOK, cv)
 }
 
@@ -65,6 +93,19 @@ func (h *handler) createCV(w http.ResponseWriter, r *http.Request) {
 	if req.Title == "" {
 		req.Title = "Untitled CV"
 	}
+	refErrs, err := h.resolveLibrary(userID, &req.Data)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load library")
+		return
+	}
+	if len(refErrs) > 0 {
+		writeValidationError(w, refErrs)
+		return
+	}
+	if errs := validation.CV(req.Title, req.Data); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
 	cv, err := h.db.CreateCV(userID, req.Title, req.Data)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to create CV")
@@ -86,6 +127,19 @@ func (h *handler) updateCV(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
+	refErrs, err := h.resolveLibrary(userID, &req.Data)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load library")
+		return
+	}
+	if len(refErrs) > 0 {
+		writeValidationError(w, refErrs)
+		return
+	}
+	if errs := validation.CV(req.Title, req.Data); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
 	cv, err := h.db.UpdateCV(id, userID, req.Title, req.Data)
 	if err != nil {
 		if err.Error() == "unauthorized" {
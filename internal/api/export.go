@@ -1,12 +1,18 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"time"
 
+	"github.com/cv-forge/cv-forge/internal/blob"
 	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/schema"
+	"github.com/cv-forge/cv-forge/internal/validation"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -36,11 +42,28 @@ func (h *handler) exportJSON(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A localized export carries only the requested locale; versions are kept
+	// as-is so the history still round-trips through import.
+	data := cv.Data
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		data = data.Localize(lang)
+	}
+
 	cvExport := models.CVExport{
-		Title:      cv.Title,
-		Data:       cv.Data,
-		ExportedAt: time.Now().UTC(),
-		Versions:   versions,
+		SchemaVersion: schema.Current,
+		Title:         cv.Title,
+		Data:          data,
+		ExportedAt:    time.Now().UTC(),
+		Versions:      versions,
+	}
+
+	// Embed the profile photo so the export is self-contained
+	if data.Personal.Photo != "" {
+		if photo, err := h.exportPhoto(data.Personal.Photo, userID); err != nil {
+			log.Printf("export photo %s: %v", data.Personal.Photo, err)
+		} else {
+			cvExport.Photo = photo
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -57,8 +80,20 @@ func (h *handler) importCV(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+	// Bring files exported by older versions up to the current schema
+	body, err = schema.UpgradeExport(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON format: "+err.Error())
+		return
+	}
+
 	var cvExport models.CVExport
-	if err := json.NewDecoder(r.Body).Decode(&cvExport); err != nil {
+	if err := json.Unmarshal(body, &cvExport); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid JSON format")
 		return
 	}
@@ -66,6 +101,46 @@ func (h *handler) importCV(w http.ResponseWriter, r *http.Request) {
 	if cvExport.Title == "" {
 		cvExport.Title = "Imported CV"
 	}
+	if err := h.detachForeignLibraryRefs(userID, &cvExport.Data); err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load library")
+		return
+	}
+	for i := range cvExport.Versions {
+		if err := h.detachForeignLibraryRefs(userID, &cvExport.Versions[i].Data); err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to load library")
+			return
+		}
+	}
+	if errs := validation.CVExport(cvExport); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	// Photo references point at the exporting user's blobs; store the
+	// embedded photo again and repoint them at the copy.
+	oldPhoto := cvExport.Data.Personal.Photo
+	newPhoto := ""
+	if cvExport.Photo != nil && len(cvExport.Photo.Data) > 0 {
+		contentType := sniffContentType(cvExport.Photo.Data, cvExport.Photo.Filename)
+		if !photoTypes[contentType] {
+			writeError(w, http.StatusUnsupportedMediaType, "unsupported photo type: "+contentType)
+			return
+		}
+		b, err := h.putBlob(userID, cvExport.Photo.Filename, contentType, bytes.NewReader(cvExport.Photo.Data), maxPhotoSize)
+		if err != nil {
+			writeUploadError(w, err)
+			return
+		}
+		newPhoto = b.ID
+	}
+	cvExport.Data.Personal.Photo = newPhoto
+	for i := range cvExport.Versions {
+		if cvExport.Versions[i].Data.Personal.Photo == oldPhoto {
+			cvExport.Versions[i].Data.Personal.Photo = newPhoto
+		} else {
+			cvExport.Versions[i].Data.Personal.Photo = ""
+		}
+	}
 
 	cv, err := h.db.CreateCV(userID, cvExport.Title, cvExport.Data)
 	if err != nil {
@@ -88,3 +163,23 @@ func (h *handler) importCV(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusCreated, cv)
 }
+
+// exportPhoto loads a photo blob for embedding in an export.
+func (h *handler) exportPhoto(id, userID string) (*models.ExportedBlob, error) {
+	b, err := h.db.GetBlob(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, blob.ErrNotFound
+	}
+	content, err := h.readBlob(b)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ExportedBlob{
+		Filename:    b.Filename,
+		ContentType: b.ContentType,
+		Data:        content,
+	}, nil
+}
@@ -0,0 +1,258 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/ical"
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/validation"
+	"github.com/go-chi/chi/v5"
+)
+
+// --- Interview handlers ---
+
+func (h *handler) listInterviews(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	appID := chi.URLParam(r, "id")
+	app, err := h.db.GetApplication(appID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get application")
+		return
+	}
+	if app == nil {
+		writeError(w, http.StatusNotFound, "application not found")
+		return
+	}
+
+	interviews, err := h.db.ListInterviews(appID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list interviews")
+		return
+	}
+	writeJSON(w, http.StatusOK, interviews)
+}
+
+func (h *handler) getInterview(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	iv, err := h.db.GetInterview(chi.URLParam(r, "id"), chi.URLParam(r, "interviewId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get interview")
+		return
+	}
+	if iv == nil {
+		writeError(w, http.StatusNotFound, "interview not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, iv)
+}
+
+func (h *handler) createInterview(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	req, ok := decodeInterview(w, r)
+	if !ok {
+		return
+	}
+
+	iv, err := h.db.CreateInterview(chi.URLParam(r, "id"), userID, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create interview")
+		return
+	}
+	if iv == nil {
+		writeError(w, http.StatusNotFound, "application not found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, iv)
+}
+
+func (h *handler) updateInterview(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	req, ok := decodeInterview(w, r)
+	if !ok {
+		return
+	}
+
+	iv, err := h.db.UpdateInterview(chi.URLParam(r, "id"), chi.URLParam(r, "interviewId"), userID, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update interview")
+		return
+	}
+	if iv == nil {
+		writeError(w, http.StatusNotFound, "interview not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, iv)
+}
+
+func (h *handler) deleteInterview(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	deleted, err := h.db.DeleteInterview(chi.URLParam(r, "id"), chi.URLParam(r, "interviewId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to delete interview")
+		return
+	}
+	if !deleted {
+		writeError(w, http.StatusNotFound, "interview not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+func (h *handler) listUpcomingInterviews(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	interviews, err := h.db.ListUpcomingInterviews(userID, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list interviews")
+		return
+	}
+	upcoming := []models.Interview{}
+	for _, iv := range interviews {
+		if iv.Outcome != models.OutcomeCancelled {
+			upcoming = append(upcoming, iv)
+		}
+	}
+	writeJSON(w, http.StatusOK, upcoming)
+}
+
+func (h *handler) interviewICS(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	iv, err := h.db.GetInterview(chi.URLParam(r, "id"), chi.URLParam(r, "interviewId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get interview")
+		return
+	}
+	if iv == nil {
+		writeError(w, http.StatusNotFound, "interview not found")
+		return
+	}
+	writeICS(w, "interview.ics", ical.Calendar{Events: []ical.Event{interviewEvent(*iv)}})
+}
+
+func (h *handler) upcomingInterviewsICS(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	interviews, err := h.db.ListUpcomingInterviews(userID, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list interviews")
+		return
+	}
+	cal := ical.Calendar{Name: "Interviews"}
+	for _, iv := range interviews {
+		cal.Events = append(cal.Events, interviewEvent(iv))
+	}
+	writeICS(w, "interviews.ics", cal)
+}
+
+// decodeInterview reads and validates an interview payload, writing the
+// error response itself when it fails.
+func decodeInterview(w http.ResponseWriter, r *http.Request) (models.InterviewRequest, bool) {
+	var req models.InterviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return req, false
+	}
+	if req.Outcome == "" {
+		req.Outcome = models.OutcomePending
+	}
+	if req.Interviewers == nil {
+		req.Interviewers = []string{}
+	}
+	if errs := validation.Interview(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return req, false
+	}
+	return req, true
+}
+
+// interviewEvent describes an interview as a calendar event.
+func interviewEvent(iv models.Interview) ical.Event {
+	kind := strings.ReplaceAll(string(iv.Type), "_", " ")
+	kind = strings.ToUpper(kind[:1]) + kind[1:]
+
+	var desc []string
+	desc = append(desc, fmt.Sprintf("%s at %s", iv.Role, iv.Company))
+	if len(iv.Interviewers) > 0 {
+		desc = append(desc, "Interviewers: "+strings.Join(iv.Interviewers, ", "))
+	}
+	if iv.VideoLink != "" {
+		desc = append(desc, "Join: "+iv.VideoLink)
+	}
+	if iv.Timezone != "" {
+		if loc, err := time.LoadLocation(iv.Timezone); err == nil {
+			desc = append(desc, "Local time: "+iv.StartsAt.In(loc).Format("Mon 2 Jan 2006 15:04 MST"))
+		}
+	}
+	if iv.Notes != "" {
+		desc = append(desc, "", iv.Notes)
+	}
+
+	location := iv.Location
+	if location == "" {
+		location = iv.VideoLink
+	}
+	return ical.Event{
+		UID:         iv.ID + "@cv-forge",
+		Start:       iv.StartsAt,
+		End:         iv.EndsAt,
+		Summary:     fmt.Sprintf("%s interview: %s", kind, iv.Company),
+		Description: strings.Join(desc, "\n"),
+		Location:    location,
+		URL:         iv.VideoLink,
+		Updated:     iv.UpdatedAt,
+		Cancelled:   iv.Outcome == models.OutcomeCancelled,
+	}
+}
+
+func writeICS(w http.ResponseWriter, filename string, cal ical.Calendar) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.WriteHeader(http.StatusOK)
+	if err := cal.Write(w); err != nil {
+		log.Printf("write calendar: %v", err)
+	}
+}
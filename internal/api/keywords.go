@@ -0,0 +1,118 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/cv-forge/cv-forge/internal/jobposting"
+	"github.com/cv-forge/cv-forge/internal/keywords"
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/validation"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxJobDescriptionSize caps how much of an attached job description is read.
+const maxJobDescriptionSize = 1 << 20
+
+// keywordMatch compares a CV, or one of its versions, with a job
+// description and reports the keywords the CV covers and misses.
+func (h *handler) keywordMatch(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.KeywordMatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if errs := validation.KeywordMatch(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	cv, err := h.db.GetCV(id, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get CV")
+		return
+	}
+	if cv == nil {
+		writeError(w, http.StatusNotFound, "CV not found")
+		return
+	}
+	data := cv.Data
+	if req.VersionID != nil && *req.VersionID != "" {
+		v, err := h.db.GetVersion(id, *req.VersionID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to get version")
+			return
+		}
+		if v == nil {
+			writeError(w, http.StatusNotFound, "version not found")
+			return
+		}
+		data = v.Data
+	}
+
+	jd := req.JobDescription
+	if jd == "" {
+		app, err := h.db.GetApplication(*req.ApplicationID, userID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to get application")
+			return
+		}
+		if app == nil {
+			writeError(w, http.StatusNotFound, "application not found")
+			return
+		}
+		jd, err = h.attachedJobDescription(app.ID, userID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to read job description")
+			return
+		}
+		if jd == "" {
+			writeValidationError(w, validation.Errors{{
+				Path:    "/applicationId",
+				Message: "application has no plain text or HTML job description attached",
+			}})
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, keywords.Match(data, jd))
+}
+
+// attachedJobDescription returns the text of the most recent plain text or
+// HTML job description attached to an application, or "" if there is none.
+func (h *handler) attachedJobDescription(appID, userID string) (string, error) {
+	atts, err := h.db.ListAttachments(appID, userID)
+	if err != nil {
+		return "", err
+	}
+	for i := len(atts) - 1; i >= 0; i-- {
+		att := atts[i]
+		mt, _, _ := mime.ParseMediaType(att.ContentType)
+		if att.Kind != models.AttachmentJobDescription || (mt != "text/plain" && mt != "text/html") {
+			continue
+		}
+		rc, err := h.blobs.Get(att.BlobID)
+		if err != nil {
+			return "", err
+		}
+		b, err := io.ReadAll(io.LimitReader(rc, maxJobDescriptionSize))
+		rc.Close()
+		if err != nil {
+			return "", err
+		}
+		if mt == "text/html" {
+			return jobposting.HTMLToText(string(b)), nil
+		}
+		return string(b), nil
+	}
+	return "", nil
+}
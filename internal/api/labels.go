@@ -0,0 +1,208 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// --- Label pack handlers ---
+
+func (h *handler) listLabelPacks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	packs, err := h.db.ListLabelPacks(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list label packs")
+		return
+	}
+	writeJSON(w, http.StatusOK, append(models.BuiltinLabelPacks(), packs...))
+}
+
+func (h *handler) getLabelPack(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	pack, err := h.findLabelPack(chi.URLParam(r, "packId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get label pack")
+		return
+	}
+	if pack == nil {
+		writeError(w, http.StatusNotFound, "label pack not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, pack)
+}
+
+func (h *handler) createLabelPack(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.LabelPackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	pack, err := h.db.CreateLabelPack(userID, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create label pack")
+		return
+	}
+	writeJSON(w, http.StatusCreated, pack)
+}
+
+func (h *handler) updateLabelPack(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "packId")
+	if strings.HasPrefix(id, models.BuiltinLabelPackPrefix) {
+		writeError(w, http.StatusForbidden, "built-in label packs cannot be modified")
+		return
+	}
+
+	var req models.LabelPackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	pack, err := h.db.UpdateLabelPack(id, userID, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update label pack")
+		return
+	}
+	if pack == nil {
+		writeError(w, http.StatusNotFound, "label pack not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, pack)
+}
+
+func (h *handler) deleteLabelPack(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "packId")
+	if strings.HasPrefix(id, models.BuiltinLabelPackPrefix) {
+		writeError(w, http.StatusForbidden, "built-in label packs cannot be deleted")
+		return
+	}
+
+	ok, err := h.db.DeleteLabelPack(id, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to delete label pack")
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "label pack not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+func (h *handler) applyLabelPack(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.ApplyLabelPackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	pack, err := h.findLabelPack(req.PackID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get label pack")
+		return
+	}
+	if pack == nil {
+		writeError(w, http.StatusNotFound, "label pack not found")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	cv, err := h.db.GetCV(id, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get CV")
+		return
+	}
+	if cv == nil {
+		writeError(w, http.StatusNotFound, "CV not found")
+		return
+	}
+
+	// Pick the translation that receives the labels
+	var key string
+	if req.Locale != nil {
+		key = cv.Data.ResolveLocale(*req.Locale)
+		if key == "" && *req.Locale != "" && !strings.EqualFold(*req.Locale, cv.Data.DefaultLocale) {
+			key = *req.Locale
+		}
+	} else {
+		key = cv.Data.ResolveLocale(pack.Locale)
+	}
+
+	labels := pack.Labels
+	if key == "" {
+		cv.Data.Labels = &labels
+	} else {
+		if cv.Data.Locales == nil {
+			cv.Data.Locales = map[string]models.LocalizedContent{}
+		}
+		loc := cv.Data.Locales[key]
+		loc.Labels = &labels
+		cv.Data.Locales[key] = loc
+	}
+
+	updated, err := h.db.UpdateCV(id, userID, cv.Title, cv.Data)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update CV")
+		return
+	}
+	if updated == nil {
+		writeError(w, http.StatusNotFound, "CV not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// findLabelPack looks up a built-in pack or one of the user's own packs.
+func (h *handler) findLabelPack(id, userID string) (*models.LabelPack, error) {
+	if strings.HasPrefix(id, models.BuiltinLabelPackPrefix) {
+		return models.BuiltinLabelPack(id), nil
+	}
+	return h.db.GetLabelPack(id, userID)
+}
@@ -0,0 +1,283 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/validation"
+	"github.com/go-chi/chi/v5"
+)
+
+// --- Content library handlers ---
+
+func (h *handler) listLibraryItems(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	items, err := h.db.ListLibraryItems(userID, models.LibraryKind(r.URL.Query().Get("kind")))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list library items")
+		return
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+func (h *handler) getLibraryItem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	item, err := h.db.GetLibraryItem(chi.URLParam(r, "itemId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get library item")
+		return
+	}
+	if item == nil {
+		writeError(w, http.StatusNotFound, "library item not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+func (h *handler) createLibraryItem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.LibraryItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	normalizeLibraryContent(&req)
+	refErrs, err := h.resolveLibraryBullets(userID, req.Content.Experience)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load library")
+		return
+	}
+	if len(refErrs) > 0 {
+		writeValidationError(w, refErrs)
+		return
+	}
+	if errs := validation.LibraryItem(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	item, err := h.db.CreateLibraryItem(userID, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create library item")
+		return
+	}
+	writeJSON(w, http.StatusCreated, item)
+}
+
+func (h *handler) updateLibraryItem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "itemId")
+	existing, err := h.db.GetLibraryItem(id, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get library item")
+		return
+	}
+	if existing == nil {
+		writeError(w, http.StatusNotFound, "library item not found")
+		return
+	}
+
+	var req models.LibraryItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Kind == "" {
+		req.Kind = existing.Kind
+	}
+	if req.Kind != existing.Kind {
+		writeValidationError(w, validation.Errors{{Path: "/kind", Message: "cannot be changed"}})
+		return
+	}
+	normalizeLibraryContent(&req)
+	refErrs, err := h.resolveLibraryBullets(userID, req.Content.Experience)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load library")
+		return
+	}
+	if len(refErrs) > 0 {
+		writeValidationError(w, refErrs)
+		return
+	}
+	if errs := validation.LibraryItem(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	// The new content reaches every CV using the item, or the edit fails
+	item, err := h.db.UpdateLibraryItem(id, userID, req)
+	if err != nil {
+		log.Printf("update library item %s: %v", id, err)
+		writeError(w, http.StatusInternalServerError, "failed to update library item")
+		return
+	}
+	if item == nil {
+		writeError(w, http.StatusNotFound, "library item not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+func (h *handler) deleteLibraryItem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "itemId")
+
+	ok, err := h.db.DeleteLibraryItem(id, userID)
+	if err != nil {
+		log.Printf("delete library item %s: %v", id, err)
+		writeError(w, http.StatusInternalServerError, "failed to delete library item")
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "library item not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+func (h *handler) libraryItemUsage(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "itemId")
+	item, err := h.db.GetLibraryItem(id, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get library item")
+		return
+	}
+	if item == nil {
+		writeError(w, http.StatusNotFound, "library item not found")
+		return
+	}
+
+	cvs, err := h.db.ListCVsReferencing(id, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to find CVs using library item")
+		return
+	}
+	usage := []models.LibraryUsage{}
+	for _, cv := range cvs {
+		for _, path := range models.LibraryRefs(cv.Data)[id] {
+			usage = append(usage, models.LibraryUsage{CVID: cv.ID, CVTitle: cv.Title, Path: path})
+		}
+	}
+	writeJSON(w, http.StatusOK, usage)
+}
+
+// --- Library helpers ---
+
+// resolveLibrary copies the content of referenced library items into data.
+// It returns validation errors for references that cannot be resolved.
+func (h *handler) resolveLibrary(userID string, data *models.CVData) (validation.Errors, error) {
+	if len(models.LibraryRefs(*data)) == 0 {
+		return nil, nil
+	}
+	items, err := h.db.LibraryItemMap(userID)
+	if err != nil {
+		return nil, err
+	}
+	return validation.LibraryRefs("/data", models.ApplyLibrary(data, items)), nil
+}
+
+// resolveLibraryBullets fills in the bullets of a library experience entry
+// that reference bullet items.
+func (h *handler) resolveLibraryBullets(userID string, exp *models.Experience) (validation.Errors, error) {
+	if exp == nil {
+		return nil, nil
+	}
+	var items map[string]models.LibraryItem
+	var errs validation.Errors
+	for i := range exp.Bullets {
+		b := &exp.Bullets[i]
+		if b.Ref == "" {
+			continue
+		}
+		if items == nil {
+			var err error
+			if items, err = h.db.LibraryItemMap(userID); err != nil {
+				return nil, err
+			}
+		}
+		item, ok := items[b.Ref]
+		if !ok || item.Kind != models.LibraryBullet {
+			errs = append(errs, validation.FieldError{
+				Path:    validation.Pointer("content", "experience", "bullets", i, "ref"),
+				Message: "references an unknown library item",
+			})
+			continue
+		}
+		b.Text = item.Content.Text
+	}
+	return errs, nil
+}
+
+// detachForeignLibraryRefs drops references to library items the user does
+// not have, e.g. in a CV exported by someone else, keeping the content.
+func (h *handler) detachForeignLibraryRefs(userID string, data *models.CVData) error {
+	refs := models.LibraryRefs(*data)
+	if len(refs) == 0 {
+		return nil
+	}
+	items, err := h.db.LibraryItemMap(userID)
+	if err != nil {
+		return err
+	}
+	for id := range refs {
+		if _, ok := items[id]; !ok {
+			models.DetachLibrary(data, id)
+		}
+	}
+	models.ApplyLibrary(data, items)
+	return nil
+}
+
+// normalizeLibraryContent clears the content fields that do not belong to
+// the item's kind.
+func normalizeLibraryContent(req *models.LibraryItemRequest) {
+	c := req.Content
+	req.Content = models.LibraryContent{}
+	switch req.Kind {
+	case models.LibraryExperience:
+		req.Content.Experience = c.Experience
+		if c.Experience != nil {
+			req.Content.Experience.Ref = ""
+		}
+	case models.LibraryBullet, models.LibrarySummary:
+		req.Content.Text = c.Text
+	case models.LibrarySkills:
+		req.Content.Skills = c.Skills
+		if c.Skills != nil {
+			req.Content.Skills.Ref = ""
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// listOptions reads the sort, order, limit and cursor query parameters of
+// a list endpoint.
+func listOptions(q url.Values) (models.ListOptions, error) {
+	opts := models.ListOptions{
+		Sort:   q.Get("sort"),
+		Order:  strings.ToLower(q.Get("order")),
+		Cursor: q.Get("cursor"),
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > models.MaxListLimit {
+			return opts, errors.New("limit must be between 1 and " + strconv.Itoa(models.MaxListLimit))
+		}
+		opts.Limit = n
+	}
+	return opts, nil
+}
+
+// applicationFilter reads the query parameters of listApplications:
+// status (repeatable), companyId, company, from and to (YYYY-MM-DD),
+// cvId and q, besides the list options.
+func applicationFilter(q url.Values) (models.ApplicationFilter, error) {
+	opts, err := listOptions(q)
+	f := models.ApplicationFilter{
+		ListOptions: opts,
+		CompanyID:   q.Get("companyId"),
+		Company:     strings.TrimSpace(q.Get("company")),
+		CVID:        q.Get("cvId"),
+		Query:       q.Get("q"),
+	}
+	if err != nil {
+		return f, err
+	}
+	for _, st := range q["status"] {
+		if st != "" {
+			f.Statuses = append(f.Statuses, models.ApplicationStatus(st))
+		}
+	}
+	for _, p := range []struct {
+		name string
+		dst  **time.Time
+	}{{"from", &f.From}, {"to", &f.To}} {
+		if v := q.Get(p.name); v != "" {
+			t, err := time.Parse("2006-01-02", v)
+			if err != nil {
+				return f, errors.New(p.name + " must be a date (YYYY-MM-DD)")
+			}
+			*p.dst = &t
+		}
+	}
+	if f.From != nil && f.To != nil && f.To.Before(*f.From) {
+		return f, errors.New("to must not be before from")
+	}
+	return f, nil
+}
+
+// writeList writes a page of a list. The cursor of the next page, if
+// there is one, goes in the X-Next-Cursor header.
+func writeList(w http.ResponseWriter, items any, next string) {
+	if next != "" {
+		w.Header().Set("X-Next-Cursor", next)
+	}
+	writeJSON(w, http.StatusOK, items)
+}
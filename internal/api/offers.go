@@ -0,0 +1,135 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cv-forge/cv-forge/internal/fx"
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// compareOffers lines up offers as yearly totals in one currency. Query
+// parameters:
+//
+//	currency  target currency (default: the base of EXCHANGE_RATES)
+//	ids       comma-separated application IDs (default: applications in an
+//	          offer stage)
+func (h *handler) compareOffers(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("currency")))
+	if currency != "" && !fx.ValidCode(currency) {
+		writeError(w, http.StatusBadRequest, "invalid currency")
+		return
+	}
+
+	apps, err := h.db.ListApplications(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list applications")
+		return
+	}
+
+	var offers []models.Application
+	if ids := r.URL.Query().Get("ids"); ids != "" {
+		byID := map[string]models.Application{}
+		for _, app := range apps {
+			byID[app.ID] = app
+		}
+		for _, id := range strings.Split(ids, ",") {
+			app, ok := byID[strings.TrimSpace(id)]
+			if !ok {
+				writeError(w, http.StatusNotFound, "application not found")
+				return
+			}
+			offers = append(offers, app)
+		}
+	} else {
+		stages, err := h.db.GetPipeline(userID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to get pipeline")
+			return
+		}
+		for _, app := range apps {
+			if st := models.FindStage(stages, app.Status); st != nil && st.Category == models.CategoryOffer {
+				offers = append(offers, app)
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, buildOfferComparison(offers, h.rates, currency))
+}
+
+// buildOfferComparison converts each offer to yearly figures in currency.
+// Without a currency it falls back to the base of the rate table, then to
+// the currency of the first offer naming one.
+func buildOfferComparison(apps []models.Application, rates fx.Rates, currency string) models.OfferComparison {
+	if currency == "" {
+		currency = rates.Base()
+	}
+	if currency == "" {
+		for _, app := range apps {
+			if app.Compensation != nil && app.Compensation.Currency != "" {
+				currency = app.Compensation.Currency
+				break
+			}
+		}
+	}
+
+	cmp := models.OfferComparison{Currency: currency, Offers: []models.OfferSummary{}}
+	for _, app := range apps {
+		s := models.OfferSummary{
+			ApplicationID: app.ID,
+			Company:       app.Company,
+			Role:          app.Role,
+			Status:        app.Status,
+			Compensation:  app.Compensation,
+		}
+		c := app.Compensation
+		switch {
+		case c == nil || (c.Min == 0 && c.Max == 0):
+			s.Problem = "no salary figures"
+		case c.Currency == "":
+			s.Problem = "no currency"
+		default:
+			max := c.Max
+			if max == 0 {
+				max = c.Min
+			}
+			perYear := c.Period.PerYear()
+			amounts := []*float64{&s.AnnualMin, &s.AnnualMax, &s.Bonus, &s.Equity}
+			values := []float64{c.Min * perYear, max * perYear, c.Bonus, c.Equity}
+			s.Comparable = true
+			for i, v := range values {
+				converted, ok := rates.Convert(v, c.Currency, currency)
+				if !ok {
+					s.Comparable = false
+					s.Problem = fmt.Sprintf("no exchange rate between %s and %s", c.Currency, currency)
+					break
+				}
+				*amounts[i] = math.Round(converted*100) / 100
+			}
+			if s.Comparable {
+				s.AnnualTotal = (s.AnnualMin+s.AnnualMax)/2 + s.Bonus + s.Equity
+			} else {
+				s.AnnualMin, s.AnnualMax, s.Bonus, s.Equity = 0, 0, 0, 0
+			}
+		}
+		cmp.Offers = append(cmp.Offers, s)
+	}
+
+	sort.SliceStable(cmp.Offers, func(i, j int) bool {
+		a, b := cmp.Offers[i], cmp.Offers[j]
+		if a.Comparable != b.Comparable {
+			return a.Comparable
+		}
+		return a.AnnualTotal > b.AnnualTotal
+	})
+	return cmp
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/cv-forge/cv-forge/internal/db"
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/validation"
+)
+
+// --- Pipeline handlers ---
+
+func (h *handler) getPipeline(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	stages, err := h.db.GetPipeline(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get pipeline")
+		return
+	}
+	writeJSON(w, http.StatusOK, stages)
+}
+
+func (h *handler) updatePipeline(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.UpdatePipelineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if errs := validation.Pipeline(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	stages, err := h.db.ReplacePipeline(userID, req.Stages)
+	if errors.Is(err, db.ErrStageInUse) {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to update pipeline")
+		return
+	}
+	writeJSON(w, http.StatusOK, stages)
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/jobposting"
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/validation"
+)
+
+// importApplicationURL creates an application from a job posting page,
+// pre-filled from its JSON-LD or meta tags. A plain-text copy of the
+// posting is attached as the job description, so it survives the page
+// being taken down.
+func (h *handler) importApplicationURL(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.ImportURLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.URL = strings.TrimSpace(req.URL)
+	if req.URL != "" && !strings.Contains(req.URL, "://") {
+		req.URL = "https://" + req.URL
+	}
+	stages, err := h.db.GetPipeline(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get pipeline")
+		return
+	}
+	if errs := validation.ImportURL(req, stages); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	posting, err := h.postings.Fetch(r.Context(), req.URL)
+	switch {
+	case errors.Is(err, jobposting.ErrInvalidURL), errors.Is(err, jobposting.ErrBlockedHost), errors.Is(err, jobposting.ErrNotHTML):
+		writeValidationError(w, validation.Errors{{Path: "/url", Message: err.Error()}})
+		return
+	case err != nil:
+		writeError(w, http.StatusBadGateway, "failed to fetch posting: "+err.Error())
+		return
+	}
+	if posting.Role == "" {
+		writeValidationError(w, validation.Errors{{Path: "/url", Message: "no job posting found on the page"}})
+		return
+	}
+
+	host := ""
+	if u, err := url.Parse(req.URL); err == nil {
+		host = strings.TrimPrefix(u.Hostname(), "www.")
+	}
+	app := models.CreateApplicationRequest{
+		Company:      posting.Company,
+		Role:         posting.Role,
+		Status:       req.Status,
+		Compensation: posting.Compensation,
+		URL:          posting.URL,
+		Source:       host,
+		Location:     posting.Location,
+		Date:         time.Now().UTC(),
+		Deadline:     posting.ValidThrough,
+	}
+	if app.Company == "" {
+		app.Company = host
+	}
+	if app.Status == "" {
+		app.Status = models.ApplicationStatus(stages[0].Name)
+	}
+	if errs := validation.CreateApplication(app, stages); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+	if !h.resolveApplicationCompany(w, userID, &app.CompanyID, &app.Company) {
+		return
+	}
+
+	created, err := h.db.CreateApplication(userID, app)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create application")
+		return
+	}
+
+	b, err := h.putBlob(userID, "job-posting.txt", "text/plain", strings.NewReader(posting.Text()), maxAttachmentSize)
+	if err == nil {
+		if _, err = h.db.CreateAttachment(created.ID, models.AttachmentJobDescription, *b); err != nil {
+			h.removeBlob(b.ID, userID)
+		}
+	}
+	if err != nil {
+		// The application is usable without the copy
+		log.Printf("store job posting for application %s: %v", created.ID, err)
+	}
+	created.Attachments, err = h.db.ListAttachments(created.ID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list attachments")
+		return
+	}
+	writeJSON(w, http.StatusCreated, created)
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/document"
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/spreadsheet"
+)
+
+// maxReportDays bounds the period of an activity report.
+const maxReportDays = 366
+
+// activityReport reports job search activity between the from and to
+// query parameters (YYYY-MM-DD, both inclusive), by default the current
+// month so far, as JSON, CSV or PDF.
+func (h *handler) activityReport(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	now := time.Now().UTC()
+	to := now.Truncate(24 * time.Hour)
+	from := time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		if v := r.URL.Query().Get(p.name); v != "" {
+			t, err := time.Parse("2006-01-02", v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, p.name+" must be a date (YYYY-MM-DD)")
+				return
+			}
+			*p.dst = t
+		}
+	}
+	if to.Before(from) {
+		writeError(w, http.StatusBadRequest, "to must not be before from")
+		return
+	}
+	if to.Sub(from) >= maxReportDays*24*time.Hour {
+		writeError(w, http.StatusBadRequest, "period must be at most "+strconv.Itoa(maxReportDays)+" days")
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" && format != "pdf" {
+		writeError(w, http.StatusBadRequest, "format must be one of: json, csv, pdf")
+		return
+	}
+
+	report, err := h.db.ActivityReport(userID, from, to, now)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to build activity report")
+		return
+	}
+	if format == "json" {
+		writeJSON(w, http.StatusOK, report)
+		return
+	}
+
+	var buf bytes.Buffer
+	contentType := "application/pdf"
+	if format == "csv" {
+		contentType = "text/csv; charset=utf-8"
+		err = spreadsheet.WriteCSV(&buf, activityTable(report))
+	} else {
+		err = document.WritePDF(&buf, activityDocument(report))
+	}
+	if errors.Is(err, document.ErrUnsupportedText) {
+		writeError(w, http.StatusUnprocessableEntity, err.Error()+"; download the report as CSV instead")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to render activity report")
+		return
+	}
+
+	filename := fmt.Sprintf("job-search-activity-%s-to-%s.%s", from.Format("2006-01-02"), to.Format("2006-01-02"), format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// activityTable lays a report out as one chronological table, with the
+// summary below it after a blank row.
+func activityTable(rep *models.ActivityReport) *spreadsheet.Table {
+	t := &spreadsheet.Table{
+		Header: []string{"Date", "Activity", "Company", "Role", "Location", "Contact method", "Outcome", "Link"},
+	}
+	apps, acts := rep.Applications, rep.Activities
+	for len(apps) > 0 || len(acts) > 0 {
+		if len(acts) == 0 || (len(apps) > 0 && !acts[0].Date.Before(apps[0].Date)) {
+			a := apps[0]
+			apps = apps[1:]
+			t.Rows = append(t.Rows, []string{a.Date.Format("2006-01-02"), "Application", a.Company, a.Role, a.Location, a.ContactMethod, string(a.Outcome), a.URL})
+			continue
+		}
+		a := acts[0]
+		acts = acts[1:]
+		t.Rows = append(t.Rows, []string{a.Date.Format("2006-01-02"), a.Description, a.Company, a.Role, "", "", "", ""})
+	}
+
+	t.Rows = append(t.Rows, nil, []string{"Summary"},
+		[]string{"Period", rep.From.Format("2006-01-02") + " to " + rep.To.Format("2006-01-02")})
+	for _, line := range activitySummary(rep) {
+		t.Rows = append(t.Rows, line[:])
+	}
+	return t
+}
+
+// activityDocument lays a report out for print: who and when, the
+// summary, then tables of the applications and the other activity.
+func activityDocument(rep *models.ActivityReport) document.Document {
+	const longDate = "2 January 2006"
+	header := []string{}
+	if rep.Name != "" {
+		header = append(header, "Name: "+rep.Name)
+	}
+	if rep.Email != "" {
+		header = append(header, "Email: "+rep.Email)
+	}
+	header = append(header,
+		"Period: "+rep.From.Format(longDate)+" to "+rep.To.Format(longDate),
+		"Generated: "+rep.GeneratedAt.Format(longDate),
+	)
+
+	var summary []string
+	for _, line := range activitySummary(rep) {
+		summary = append(summary, line[0]+": "+line[1])
+	}
+	doc := document.Document{
+		Title: "Job search activity report",
+		Blocks: []document.Block{
+			{Kind: document.Heading, Text: "Job search activity report"},
+			{Kind: document.Paragraph, Text: strings.Join(header, "\n")},
+			{Kind: document.Heading, Text: "Summary"},
+			{Kind: document.Paragraph, Text: strings.Join(summary, "\n")},
+			{Kind: document.Heading, Text: "Applications"},
+		},
+	}
+
+	if len(rep.Applications) == 0 {
+		doc.Blocks = append(doc.Blocks, document.Block{Kind: document.Paragraph, Text: "No applications in this period."})
+	} else {
+		rows := [][]string{{"Date", "Company", "Role", "Location", "Contact method", "Outcome"}}
+		for _, a := range rep.Applications {
+			rows = append(rows, []string{a.Date.Format("2 Jan 2006"), a.Company, a.Role, a.Location, a.ContactMethod, string(a.Outcome)})
+		}
+		doc.Blocks = append(doc.Blocks, document.Block{Kind: document.Table, Rows: rows, Widths: []float64{1.1, 1.6, 1.8, 1.2, 1.3, 1.1}})
+	}
+
+	doc.Blocks = append(doc.Blocks, document.Block{Kind: document.Heading, Text: "Contact with employers"})
+	if len(rep.Activities) == 0 {
+		doc.Blocks = append(doc.Blocks, document.Block{Kind: document.Paragraph, Text: "No interviews, calls or emails logged in this period."})
+	} else {
+		rows := [][]string{{"Date", "Company", "Role", "Activity"}}
+		for _, a := range rep.Activities {
+			rows = append(rows, []string{a.Date.Format("2 Jan 2006"), a.Company, a.Role, a.Description})
+		}
+		doc.Blocks = append(doc.Blocks, document.Block{Kind: document.Table, Rows: rows, Widths: []float64{1.1, 1.5, 1.6, 2.8}})
+	}
+	return doc
+}
+
+// activitySummary returns the summary of a report as label and value
+// pairs.
+func activitySummary(rep *models.ActivityReport) [][2]string {
+	s := rep.Summary
+	lines := [][2]string{
+		{"Applications made", strconv.Itoa(s.Applications)},
+		{"Companies applied to", strconv.Itoa(s.Companies)},
+		{"Interviews", strconv.Itoa(s.Interviews)},
+		{"Calls and emails with employers", strconv.Itoa(s.Contacts)},
+	}
+	if len(s.Outcomes) > 0 {
+		outcomes := make([]string, len(s.Outcomes))
+		for i, o := range s.Outcomes {
+			outcomes[i] = fmt.Sprintf("%d %s", o.Count, o.Status)
+		}
+		lines = append(lines, [2]string{"Outcomes at " + rep.To.Format("2006-01-02"), strings.Join(outcomes, ", ")})
+	}
+	for _, wk := range s.PerWeek {
+		lines = append(lines, [2]string{"Applications in the week of " + wk.Week, strconv.Itoa(wk.Count)})
+	}
+	return lines
+}
@@ -4,21 +4,26 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/cv-forge/cv-forge/internal/blob"
 	"github.com/cv-forge/cv-forge/internal/db"
+	"github.com/cv-forge/cv-forge/internal/fx"
+	"github.com/cv-forge/cv-forge/internal/jobposting"
+	"github.com/cv-forge/cv-forge/internal/validation"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
 // NewRouter creates and configures the Chi router with all API routes.
-func NewRouter(database *db.DB, staticFS http.FileSystem) *chi.Mux {
+func NewRouter(database *db.DB, blobs blob.Store, rates fx.Rates, staticFS http.FileSystem) *chi.Mux {
 	r := chi.NewRouter()
 
 	// Middleware
+	r.Use(redactFeedToken)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Compress(5))
 
-	h := &handler{db: database}
+	h := &handler{db: database, blobs: blobs, rates: rates, postings: jobposting.NewFetcherFromEnv()}
 
 	// API routes
 	r.Route("/api", func(r chi.Router) {
@@ -54,6 +59,13 @@ func NewRouter(database *db.DB, staticFS http.FileSystem) *chi.Mux {
 				// Export
 				r.Get("/export/json", h.exportJSON)
 
+				// Labels
+				r.Post("/labels/apply", h.applyLabelPack)
+
+				// Tailoring
+				r.Post("/assemble", h.assembleCV)
+				r.Post("/keyword-match", h.keywordMatch)
+
 				// Versions
 				r.Get("/versions", h.listVersions)
 				r.Post("/versions", h.createVersion)
@@ -61,17 +73,130 @@ func NewRouter(database *db.DB, staticFS http.FileSystem) *chi.Mux {
 				r.Post("/versions/{vid}/restore", h.restoreVersion)
 			})
 
+			// Label packs
+			r.Get("/label-packs", h.listLabelPacks)
+			r.Post("/label-packs", h.createLabelPack)
+			r.Route("/label-packs/{packId}", func(r chi.Router) {
+				r.Get("/", h.getLabelPack)
+				r.Put("/", h.updateLabelPack)
+				r.Delete("/", h.deleteLabelPack)
+			})
+
+			// Cover letter templates
+			r.Get("/cover-letter-templates", h.listCoverLetterTemplates)
+			r.Post("/cover-letter-templates", h.createCoverLetterTemplate)
+			r.Route("/cover-letter-templates/{templateId}", func(r chi.Router) {
+				r.Get("/", h.getCoverLetterTemplate)
+				r.Put("/", h.updateCoverLetterTemplate)
+				r.Delete("/", h.deleteCoverLetterTemplate)
+			})
+
+			// Content library
+			r.Get("/library", h.listLibraryItems)
+			r.Post("/library", h.createLibraryItem)
+			r.Route("/library/{itemId}", func(r chi.Router) {
+				r.Get("/", h.getLibraryItem)
+				r.Put("/", h.updateLibraryItem)
+				r.Delete("/", h.deleteLibraryItem)
+				r.Get("/usage", h.libraryItemUsage)
+			})
+
+			// Blobs (profile photos)
+			r.Post("/blobs", h.uploadBlob)
+			r.Get("/blobs/{blobId}", h.getBlob)
+			r.Delete("/blobs/{blobId}", h.deleteBlob)
+
 			// Job Applications
+			r.Get("/pipeline", h.getPipeline)
+			r.Put("/pipeline", h.updatePipeline)
+
+			r.Get("/calendar-feed", h.getCalendarFeed)
+			r.Post("/calendar-feed", h.rotateCalendarFeed)
+			r.Delete("/calendar-feed", h.deleteCalendarFeed)
+
+			r.Get("/companies", h.listCompanies)
+			r.Post("/companies", h.createCompany)
+			r.Get("/companies/duplicates", h.listDuplicateCompanies)
+			r.Post("/companies/merge", h.mergeCompanies)
+			r.Route("/companies/{companyId}", func(r chi.Router) {
+				r.Get("/", h.getCompany)
+				r.Put("/", h.updateCompany)
+				r.Delete("/", h.deleteCompany)
+			})
+
+			r.Get("/contacts", h.listContacts)
+			r.Post("/contacts", h.createContact)
+			r.Route("/contacts/{contactId}", func(r chi.Router) {
+				r.Get("/", h.getContact)
+				r.Put("/", h.updateContact)
+				r.Delete("/", h.deleteContact)
+				r.Post("/interactions", h.createInteraction)
+				r.Delete("/interactions/{interactionId}", h.deleteInteraction)
+			})
+
+			r.Get("/analytics/applications", h.applicationAnalytics)
+			r.Get("/analytics/cvs", h.cvEffectiveness)
+			r.Get("/offers/compare", h.compareOffers)
+			r.Get("/reports/activity", h.activityReport)
+
+			r.Get("/interviews/upcoming", h.listUpcomingInterviews)
+			r.Get("/interviews/upcoming.ics", h.upcomingInterviewsICS)
+
 			r.Get("/applications", h.listApplications)
 			r.Post("/applications", h.createApplication)
+			r.Post("/applications/import-url", h.importApplicationURL)
+			r.Get("/applications/export", h.exportApplications)
+			r.Post("/applications/import", h.importApplications)
 			r.Route("/applications/{id}", func(r chi.Router) {
 				r.Get("/", h.getApplication)
 				r.Put("/", h.updateApplication)
 				r.Delete("/", h.deleteApplication)
+
+				// Attachments
+				r.Get("/attachments", h.listAttachments)
+				r.Post("/attachments", h.uploadAttachment)
+				r.Get("/attachments/{attachmentId}", h.downloadAttachment)
+				r.Delete("/attachments/{attachmentId}", h.deleteAttachment)
+
+				// Interviews
+				r.Get("/interviews", h.listInterviews)
+				r.Post("/interviews", h.createInterview)
+				r.Get("/interviews/{interviewId}", h.getInterview)
+				r.Put("/interviews/{interviewId}", h.updateInterview)
+				r.Delete("/interviews/{interviewId}", h.deleteInterview)
+				r.Get("/interviews/{interviewId}/ics", h.interviewICS)
+
+				// Cover letters
+				r.Get("/cover-letters", h.listCoverLetters)
+				r.Post("/cover-letters", h.createCoverLetter)
+				r.Post("/cover-letters/preview", h.previewCoverLetter)
+				r.Route("/cover-letters/{letterId}", func(r chi.Router) {
+					r.Get("/", h.getCoverLetter)
+					r.Put("/", h.updateCoverLetter)
+					r.Delete("/", h.deleteCoverLetter)
+					r.Get("/export/{format}", h.exportCoverLetter)
+					r.Get("/versions", h.listCoverLetterVersions)
+					r.Post("/versions", h.createCoverLetterVersion)
+					r.Get("/versions/{vid}", h.getCoverLetterVersion)
+					r.Post("/versions/{vid}/restore", h.restoreCoverLetterVersion)
+				})
+
+				// Contacts
+				r.Get("/contacts", h.listApplicationContacts)
+				r.Put("/contacts/{contactId}", h.linkContact)
+				r.Delete("/contacts/{contactId}", h.unlinkContact)
+
+				// Timeline
+				r.Get("/timeline", h.getTimeline)
+				r.Post("/events", h.createEvent)
+				r.Delete("/events/{eventId}", h.deleteEvent)
 			})
 		})
 	})
 
+	// Calendar subscription feed, authenticated by the token in the URL
+	r.Get("/calendar/{token}.ics", h.calendarFeed)
+
 	// Static files (frontend)
 	fileServer := http.FileServer(staticFS)
 	r.Get("/*", func(w http.ResponseWriter, r *http.Request) {
@@ -97,3 +222,10 @@ func writeJSON(w http.ResponseWriter, status int, v any) {
 func writeError(w http.ResponseWriter, status int, msg string) {
 	writeJSON(w, status, map[string]string{"error": msg})
 }
+
+func writeValidationError(w http.ResponseWriter, errs validation.Errors) {
+	writeJSON(w, http.StatusUnprocessableEntity, map[string]any{
+		"error":  "validation failed",
+		"fields": errs,
+	})
+}
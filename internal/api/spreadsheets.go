@@ -0,0 +1,195 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/apptable"
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/spreadsheet"
+	"github.com/cv-forge/cv-forge/internal/validation"
+)
+
+// maxImportSize is the upload limit for spreadsheet imports.
+const maxImportSize = 10 << 20
+
+func (h *handler) exportApplications(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "xlsx" {
+		writeValidationError(w, validation.Errors{{Path: "/format", Message: "must be one of: csv, xlsx"}})
+		return
+	}
+	apps, err := h.db.ListApplications(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list applications")
+		return
+	}
+
+	t := apptable.Export(apps)
+	var buf bytes.Buffer
+	contentType := "text/csv; charset=utf-8"
+	if format == "xlsx" {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		err = spreadsheet.WriteXLSX(&buf, t, "Applications")
+	} else {
+		err = spreadsheet.WriteCSV(&buf, t)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to export applications")
+		return
+	}
+
+	filename := "applications-" + time.Now().UTC().Format("2006-01-02") + "." + format
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// importApplications creates applications from a CSV or XLSX file, sent as
+// the "file" field of a multipart form with import options as JSON in an
+// optional "options" field. With ?dryRun=true nothing is created and the
+// response previews how each row would be imported. Otherwise valid rows
+// are created and invalid ones reported; if creating fails, no row is
+// created.
+func (h *handler) importApplications(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+	file, filename, opts, err := readImportForm(w, r)
+	if err != nil {
+		writeUploadError(w, err)
+		return
+	}
+
+	var t *spreadsheet.Table
+	switch sniffContentType(file, filename) {
+	case "application/zip", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":
+		t, err = spreadsheet.ReadXLSX(bytes.NewReader(file), int64(len(file)))
+	case "text/plain", "text/csv", "application/octet-stream":
+		t, err = spreadsheet.ReadCSV(bytes.NewReader(file))
+	default:
+		writeError(w, http.StatusUnsupportedMediaType, "file must be CSV or XLSX")
+		return
+	}
+	if err != nil {
+		writeValidationError(w, validation.Errors{{Path: "/file", Message: err.Error()}})
+		return
+	}
+
+	stages, err := h.db.GetPipeline(userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get pipeline")
+		return
+	}
+	res, err := apptable.Convert(t, stages, opts, time.Now().UTC())
+	if err != nil {
+		writeValidationError(w, validation.Errors{{Path: "/options", Message: err.Error()}})
+		return
+	}
+	res.DryRun = dryRun
+	if dryRun {
+		writeJSON(w, http.StatusOK, res)
+		return
+	}
+
+	var valid []int
+	var reqs []models.CreateApplicationRequest
+	for i, row := range res.Rows {
+		if len(row.Errors) == 0 {
+			valid = append(valid, i)
+			reqs = append(reqs, row.Application)
+		}
+	}
+	apps, err := h.db.ImportApplications(userID, reqs)
+	if err != nil {
+		log.Printf("import applications: %v", err)
+		writeError(w, http.StatusInternalServerError, "failed to import applications")
+		return
+	}
+	for n, i := range valid {
+		res.Rows[i].ApplicationID = &apps[n].ID
+	}
+	res.Created = len(apps)
+	writeJSON(w, http.StatusOK, res)
+}
+
+// readImportForm reads the file and options of an import. Like other
+// uploads the file may instead be the raw request body, with options left
+// at their defaults.
+func readImportForm(w http.ResponseWriter, r *http.Request) (file []byte, filename string, opts apptable.Options, err error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize+(64<<10))
+	filename = r.URL.Query().Get("filename")
+
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt != "multipart/form-data" {
+		file, err = io.ReadAll(io.LimitReader(r.Body, maxImportSize+1))
+		switch {
+		case err != nil:
+			err = uploadReadError(err)
+		case len(file) == 0:
+			err = errUploadMissing
+		case len(file) > maxImportSize:
+			err = errUploadTooLarge
+		}
+		return file, filename, opts, err
+	}
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, "", opts, errUploadMalformed
+	}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, "", opts, uploadReadError(err)
+		}
+		switch part.FormName() {
+		case "file":
+			if part.FileName() != "" {
+				filename = part.FileName()
+			}
+			file, err = io.ReadAll(io.LimitReader(part, maxImportSize+1))
+			if err != nil {
+				return nil, "", opts, uploadReadError(err)
+			}
+			if len(file) > maxImportSize {
+				return nil, "", opts, errUploadTooLarge
+			}
+		case "options":
+			if err := json.NewDecoder(part).Decode(&opts); err != nil {
+				var maxErr *http.MaxBytesError
+				if errors.As(err, &maxErr) {
+					return nil, "", opts, errUploadTooLarge
+				}
+				return nil, "", opts, errors.New("invalid import options")
+			}
+		}
+	}
+	if len(file) == 0 {
+		return nil, "", opts, errUploadMissing
+	}
+	return file, filename, opts, nil
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/validation"
+	"github.com/go-chi/chi/v5"
+)
+
+// --- Timeline handlers ---
+
+func (h *handler) getTimeline(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	events, err := h.db.ListEvents(chi.URLParam(r, "id"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get timeline")
+		return
+	}
+	if events == nil {
+		writeError(w, http.StatusNotFound, "application not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, models.Timeline{
+		Events: events,
+		Stages: models.StageSpans(events, time.Now().UTC()),
+	})
+}
+
+func (h *handler) createEvent(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.CreateEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if errs := validation.Event(req); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	event, err := h.db.CreateEvent(chi.URLParam(r, "id"), userID, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to create event")
+		return
+	}
+	if event == nil {
+		writeError(w, http.StatusNotFound, "application not found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, event)
+}
+
+func (h *handler) deleteEvent(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	deleted, err := h.db.DeleteEvent(chi.URLParam(r, "id"), chi.URLParam(r, "eventId"), userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to delete event")
+		return
+	}
+	if !deleted {
+		writeError(w, http.StatusNotFound, "event not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
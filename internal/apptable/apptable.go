@@ -0,0 +1,159 @@
+// Package apptable converts between job applications and spreadsheet
+// tables, for bulk import and export.
+package apptable
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/spreadsheet"
+)
+
+// Fields that spreadsheet columns map onto. Salary is free text parsed like
+// the salary of an application; the other compensation fields are
+// structured and take precedence.
+const (
+	FieldCompany    = "company"
+	FieldRole       = "role"
+	FieldStatus     = "status"
+	FieldDate       = "date"
+	FieldLocation   = "location"
+	FieldSource     = "source"
+	FieldURL        = "url"
+	FieldSalary     = "salary"
+	FieldSalaryMin  = "salaryMin"
+	FieldSalaryMax  = "salaryMax"
+	FieldCurrency   = "currency"
+	FieldPeriod     = "period"
+	FieldBonus      = "bonus"
+	FieldEquity     = "equity"
+	FieldDeadline   = "deadline"
+	FieldFollowUpAt = "followUpAt"
+	FieldNotes      = "notes"
+)
+
+// fields lists the fields in export order, with the header they are
+// exported under and the headers they are recognised by on import.
+var fields = []struct {
+	name, header string
+	synonyms     []string
+}{
+	{FieldCompany, "Company", []string{"company", "company name", "employer", "organisation", "organization", "firm"}},
+	{FieldRole, "Role", []string{"role", "position", "job title", "title", "job", "position title"}},
+	{FieldStatus, "Status", []string{"status", "stage", "state", "application status"}},
+	{FieldDate, "Date", []string{"date", "date applied", "applied", "applied on", "applied date", "application date", "submitted"}},
+	{FieldLocation, "Location", []string{"location", "city", "place"}},
+	{FieldSource, "Source", []string{"source", "channel", "found on", "found via", "platform"}},
+	{FieldURL, "URL", []string{"url", "link", "job link", "job url", "posting", "posting url"}},
+	{FieldSalary, "Salary", []string{"salary", "salary range", "compensation", "pay"}},
+	{FieldSalaryMin, "Salary min", []string{"salary min", "min salary", "minimum salary", "salary from"}},
+	{FieldSalaryMax, "Salary max", []string{"salary max", "max salary", "maximum salary", "salary to"}},
+	{FieldCurrency, "Currency", []string{"currency", "salary currency"}},
+	{FieldPeriod, "Pay period", []string{"pay period", "period", "salary period"}},
+	{FieldBonus, "Bonus", []string{"bonus"}},
+	{FieldEquity, "Equity", []string{"equity", "stock"}},
+	{FieldDeadline, "Deadline", []string{"deadline", "closing date", "apply by"}},
+	{FieldFollowUpAt, "Follow up", []string{"follow up", "follow up date", "followup", "follow up at"}},
+	{FieldNotes, "Notes", []string{"notes", "note", "comments", "comment"}},
+}
+
+func isField(name string) bool {
+	for _, f := range fields {
+		if f.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeHeader makes headers comparable: "Date_Applied:" and
+// "date applied" are the same column.
+func normalizeHeader(h string) string {
+	h = strings.ToLower(h)
+	h = strings.NewReplacer("_", " ", "-", " ", ".", " ", ":", " ").Replace(h)
+	return strings.Join(strings.Fields(h), " ")
+}
+
+// resolveMapping returns the column index of each mapped field. Explicit
+// mappings win; an explicit empty mapping leaves a field out. Other fields
+// are matched to columns by their header.
+func resolveMapping(header []string, explicit map[string]string) (map[string]int, error) {
+	columns := map[string]int{}
+	for i, h := range header {
+		if _, dup := columns[normalizeHeader(h)]; !dup {
+			columns[normalizeHeader(h)] = i
+		}
+	}
+
+	mapping := map[string]int{}
+	used := map[int]bool{}
+	for field, h := range explicit {
+		if !isField(field) {
+			return nil, fmt.Errorf("unknown field %q in mapping", field)
+		}
+		if h == "" {
+			continue
+		}
+		i, ok := columns[normalizeHeader(h)]
+		if !ok {
+			return nil, fmt.Errorf("column %q mapped to %s is not in the file", h, field)
+		}
+		mapping[field] = i
+		used[i] = true
+	}
+	for _, f := range fields {
+		if _, ok := explicit[f.name]; ok {
+			continue
+		}
+		for _, s := range f.synonyms {
+			if i, ok := columns[s]; ok && !used[i] {
+				mapping[f.name] = i
+				used[i] = true
+				break
+			}
+		}
+	}
+	return mapping, nil
+}
+
+// Export turns applications into a table with one row per application.
+func Export(apps []models.Application) *spreadsheet.Table {
+	t := &spreadsheet.Table{Rows: make([][]string, 0, len(apps))}
+	for _, f := range fields {
+		if f.name != FieldSalary {
+			t.Header = append(t.Header, f.header)
+		}
+	}
+	for _, app := range apps {
+		row := []string{app.Company, app.Role, string(app.Status), formatDate(&app.Date), app.Location, app.Source, app.URL}
+		if c := app.Compensation; c != nil {
+			row = append(row, formatAmount(c.Min), formatAmount(c.Max), c.Currency, string(c.Period), formatAmount(c.Bonus), formatAmount(c.Equity))
+		} else {
+			row = append(row, "", "", "", "", "", "")
+		}
+		row = append(row, formatDate(app.Deadline), formatDate(app.FollowUpAt), app.Notes)
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+// formatDate writes a date as YYYY-MM-DD, with the time if it has one.
+func formatDate(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	if h, m, s := t.Clock(); h == 0 && m == 0 && s == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+func formatAmount(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,419 @@
+package apptable
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/spreadsheet"
+	"github.com/cv-forge/cv-forge/internal/validation"
+)
+
+// DateOrder is how ambiguous numeric dates such as 03/04/2025 are read.
+type DateOrder string
+
+const (
+	DayMonthYear DateOrder = "dmy"
+	MonthDayYear DateOrder = "mdy"
+)
+
+// Options control how a table is imported. All are optional.
+type Options struct {
+	Mapping   map[string]string `json:"mapping"`   // Field to column header; fields left out are detected from the headers, "" skips a field
+	StatusMap map[string]string `json:"statusMap"` // Status in the file to pipeline stage name
+	DateOrder DateOrder         `json:"dateOrder"` // Detected from the dates in the file when empty
+}
+
+// Result describes an import: how the file was read and the application
+// each row becomes.
+type Result struct {
+	DryRun    bool              `json:"dryRun"`
+	Columns   []string          `json:"columns"`
+	Mapping   map[string]string `json:"mapping"`  // Field to the column it was read from
+	Unmapped  []string          `json:"unmapped"` // Columns that were not imported
+	DateOrder DateOrder         `json:"dateOrder"`
+	Statuses  []StatusMapping   `json:"statuses"`
+	Rows      []Row             `json:"rows"`
+	Valid     int               `json:"valid"`
+	Invalid   int               `json:"invalid"`
+	Created   int               `json:"created"`
+}
+
+// StatusMapping is a distinct status found in the file and the stage it
+// maps to, empty when it matches none.
+type StatusMapping struct {
+	Value string `json:"value"`
+	Stage string `json:"stage"`
+	Count int    `json:"count"`
+}
+
+// Row is one data row of the file. Rows with errors are not imported.
+type Row struct {
+	Row           int                             `json:"row"` // Row number in the file, counting the header
+	Application   models.CreateApplicationRequest `json:"application"`
+	Errors        validation.Errors               `json:"errors"`
+	ApplicationID *string                         `json:"applicationId"` // Set once created
+}
+
+// Convert reads the rows of t as applications against the given pipeline.
+// Each row is validated like a created application; an error is returned
+// only for options that cannot apply to the file.
+func Convert(t *spreadsheet.Table, stages []models.PipelineStage, opts Options, now time.Time) (*Result, error) {
+	if len(stages) == 0 {
+		return nil, fmt.Errorf("pipeline has no stages")
+	}
+	mapping, err := resolveMapping(t.Header, opts.Mapping)
+	if err != nil {
+		return nil, err
+	}
+	for value, stage := range opts.StatusMap {
+		if models.FindStage(stages, models.ApplicationStatus(stage)) == nil {
+			return nil, fmt.Errorf("status %q is mapped to %q, which is not a pipeline stage", value, stage)
+		}
+	}
+	order := opts.DateOrder
+	switch order {
+	case DayMonthYear, MonthDayYear:
+	case "":
+		order = detectDateOrder(t, mapping)
+	default:
+		return nil, fmt.Errorf("date order must be dmy or mdy")
+	}
+
+	res := &Result{
+		Columns:   t.Header,
+		Mapping:   map[string]string{},
+		Unmapped:  []string{},
+		DateOrder: order,
+		Statuses:  []StatusMapping{},
+		Rows:      make([]Row, 0, len(t.Rows)),
+	}
+	mapped := map[int]bool{}
+	for field, i := range mapping {
+		res.Mapping[field] = t.Header[i]
+		mapped[i] = true
+	}
+	for i, h := range t.Header {
+		if !mapped[i] && h != "" {
+			res.Unmapped = append(res.Unmapped, h)
+		}
+	}
+
+	statuses := map[string]int{}
+	for i := range t.Rows {
+		cell := func(field string) string {
+			if j, ok := mapping[field]; ok {
+				return t.Cell(i, j)
+			}
+			return ""
+		}
+		row := Row{Row: i + 2}
+		if i < len(t.Numbers) {
+			row.Row = t.Numbers[i]
+		}
+		row.Application, row.Errors = convertRow(cell, stages, opts.StatusMap, order, now)
+		failed := map[string]bool{}
+		for _, fe := range row.Errors {
+			failed[fe.Path] = true
+		}
+		for _, fe := range validation.CreateApplication(row.Application, stages) {
+			if !failed[fe.Path] {
+				row.Errors = append(row.Errors, fe)
+			}
+		}
+		if len(row.Errors) == 0 {
+			res.Valid++
+		} else {
+			res.Invalid++
+		}
+		res.Rows = append(res.Rows, row)
+
+		value := cell(FieldStatus)
+		if _, seen := statuses[value]; !seen {
+			statuses[value] = len(res.Statuses)
+			stage, _ := mapStatus(value, stages, opts.StatusMap)
+			res.Statuses = append(res.Statuses, StatusMapping{Value: value, Stage: stage})
+		}
+		res.Statuses[statuses[value]].Count++
+	}
+	return res, nil
+}
+
+// convertRow builds the application of one row. Errors are for cells that
+// could not be read; the application is validated separately.
+func convertRow(cell func(string) string, stages []models.PipelineStage, statusMap map[string]string, order DateOrder, now time.Time) (models.CreateApplicationRequest, validation.Errors) {
+	var errs validation.Errors
+	fail := func(path, format string, args ...any) {
+		errs = append(errs, validation.FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	req := models.CreateApplicationRequest{
+		Company:  cell(FieldCompany),
+		Role:     cell(FieldRole),
+		URL:      cell(FieldURL),
+		Source:   cell(FieldSource),
+		Location: cell(FieldLocation),
+		Notes:    cell(FieldNotes),
+	}
+
+	if value := cell(FieldStatus); value != "" {
+		if stage, ok := mapStatus(value, stages, statusMap); ok {
+			req.Status = models.ApplicationStatus(stage)
+		} else {
+			fail("/status", "%q does not match a pipeline stage; map it in statusMap", value)
+		}
+	} else {
+		req.Status = models.ApplicationStatus(stages[0].Name)
+	}
+
+	req.Date = now
+	if value := cell(FieldDate); value != "" {
+		if d, ok := parseDate(value, order); ok {
+			req.Date = d
+		} else {
+			fail("/date", "%q is not a date", value)
+		}
+	}
+	for _, f := range []struct {
+		field, path string
+		dst         **time.Time
+	}{{FieldDeadline, "/deadline", &req.Deadline}, {FieldFollowUpAt, "/followUpAt", &req.FollowUpAt}} {
+		if value := cell(f.field); value != "" {
+			if d, ok := parseDate(value, order); ok {
+				*f.dst = &d
+			} else {
+				fail(f.path, "%q is not a date", value)
+			}
+		}
+	}
+
+	req.Compensation = compensation(cell, fail)
+	return req, errs
+}
+
+// compensation reads the structured compensation columns, or failing that
+// parses the salary column. It returns nil when the row has neither.
+func compensation(cell func(string) string, fail func(path, format string, args ...any)) *models.Compensation {
+	salary := cell(FieldSalary)
+	structured := false
+	for _, f := range []string{FieldSalaryMin, FieldSalaryMax, FieldCurrency, FieldPeriod, FieldBonus, FieldEquity} {
+		if cell(f) != "" {
+			structured = true
+		}
+	}
+	if !structured {
+		return models.ParseSalary(salary)
+	}
+
+	c := &models.Compensation{Period: models.PeriodYear, Benefits: []string{}, Note: salary}
+	for _, f := range []struct {
+		field, path string
+		dst         *float64
+	}{
+		{FieldSalaryMin, "/compensation/min", &c.Min},
+		{FieldSalaryMax, "/compensation/max", &c.Max},
+		{FieldBonus, "/compensation/bonus", &c.Bonus},
+		{FieldEquity, "/compensation/equity", &c.Equity},
+	} {
+		value := cell(f.field)
+		if value == "" {
+			continue
+		}
+		parsed := models.ParseSalary(value)
+		if parsed.Min == 0 && strings.Trim(value, "0.,") != "" {
+			fail(f.path, "%q is not an amount", value)
+			continue
+		}
+		*f.dst = parsed.Min
+		if c.Currency == "" {
+			c.Currency = parsed.Currency
+		}
+	}
+	if c.Max == 0 {
+		c.Max = c.Min
+	}
+	if value := cell(FieldCurrency); value != "" {
+		if parsed := models.ParseSalary(value); parsed.Currency != "" {
+			c.Currency = parsed.Currency
+		} else {
+			c.Currency = strings.ToUpper(value)
+		}
+	}
+	if value := cell(FieldPeriod); value != "" {
+		p := models.PayPeriod(strings.ToLower(value))
+		if !p.Valid() {
+			p = parsePeriod(value)
+		}
+		if p == "" {
+			fail("/compensation/period", "%q is not a pay period", value)
+		} else {
+			c.Period = p
+		}
+	}
+	return c
+}
+
+// parsePeriod reads pay periods as spreadsheets tend to write them.
+func parsePeriod(s string) models.PayPeriod {
+	switch normalizeHeader(s) {
+	case "annual", "annually", "yearly", "per year", "per annum", "pa", "p a", "yr":
+		return models.PeriodYear
+	case "monthly", "per month", "pcm", "pm", "mo":
+		return models.PeriodMonth
+	case "weekly", "per week", "pw", "wk":
+		return models.PeriodWeek
+	case "daily", "per day", "pd", "day rate":
+		return models.PeriodDay
+	case "hourly", "per hour", "ph", "hr":
+		return models.PeriodHour
+	}
+	return ""
+}
+
+// statusKeywords map words of common status names to the category of the
+// stage they mean, checked in order: "offer declined" is closed, not an
+// offer.
+var statusKeywords = []struct {
+	category models.StageCategory
+	words    []string
+}{
+	{models.CategoryClosed, []string{"withdrawn", "withdrew", "ghosted", "closed", "cancelled", "canceled", "declined", "expired", "archived", "no response"}},
+	{models.CategoryRejected, []string{"reject", "unsuccessful", "not selected", "turned down", "denied"}},
+	{models.CategoryOffer, []string{"offer", "accepted", "hired"}},
+	{models.CategoryInterview, []string{"interview", "screen", "onsite", "on site", "assessment", "technical", "take home", "call"}},
+	{models.CategoryApplied, []string{"applied", "submitted", "sent", "pending", "waiting", "in review"}},
+}
+
+// mapStatus finds the stage a status from the file means: the stage it is
+// mapped to, the stage of the same name, or the first stage of the
+// category its wording suggests, if the pipeline has one.
+func mapStatus(value string, stages []models.PipelineStage, statusMap map[string]string) (string, bool) {
+	if value == "" {
+		return stages[0].Name, true
+	}
+	if stage, ok := statusMap[value]; ok {
+		return stage, true
+	}
+	for from, stage := range statusMap {
+		if strings.EqualFold(from, value) {
+			return stage, true
+		}
+	}
+	for _, st := range stages {
+		if strings.EqualFold(st.Name, value) {
+			return st.Name, true
+		}
+	}
+
+	lower := normalizeHeader(value)
+	for _, k := range statusKeywords {
+		for _, w := range k.words {
+			if !strings.Contains(lower, w) {
+				continue
+			}
+			for _, st := range stages {
+				if st.Category == k.category {
+					return st.Name, true
+				}
+			}
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// numericDateRe matches dates such as 3/4/2025, 03.04.25 or 3-4-2025.
+var numericDateRe = regexp.MustCompile(`^(\d{1,2})[./-](\d{1,2})[./-](\d{4}|\d{2})(?:\s+(\d{1,2}):(\d{2}))?$`)
+
+// dateLayouts are the unambiguous formats dates are read in.
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+	"2006/01/02",
+	"2006.01.02",
+	"2 Jan 2006",
+	"2 January 2006",
+	"2-Jan-2006",
+	"2-Jan-06",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"Jan 2 2006",
+	"January 2 2006",
+	"Mon, 2 Jan 2006",
+	"Monday, January 2, 2006",
+}
+
+// parseDate reads a date in one of the common spreadsheet formats. A
+// bare number is taken as an Excel serial date, as in CSV exported from an
+// unformatted column. Dates with an offset are converted to UTC.
+func parseDate(s string, order DateOrder) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), true
+		}
+	}
+	if m := numericDateRe.FindStringSubmatch(s); m != nil {
+		a, _ := strconv.Atoi(m[1])
+		b, _ := strconv.Atoi(m[2])
+		year, _ := strconv.Atoi(m[3])
+		if len(m[3]) == 2 {
+			year += 2000
+		}
+		day, month := a, b
+		if order == MonthDayYear {
+			day, month = b, a
+		}
+		hour, minute := 0, 0
+		if m[4] != "" {
+			hour, _ = strconv.Atoi(m[4])
+			minute, _ = strconv.Atoi(m[5])
+		}
+		t := time.Date(year, time.Month(month), day, hour, minute, 0, 0, time.UTC)
+		// time.Date normalizes 31/02 to March; reject it instead
+		if t.Day() != day || int(t.Month()) != month || hour > 23 || minute > 59 {
+			return time.Time{}, false
+		}
+		return t, true
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && f >= 20000 && f < 80000 {
+		days := math.Floor(f)
+		t := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(days))
+		return t.Add(time.Duration((f - days) * 24 * float64(time.Hour)).Round(time.Minute)), true
+	}
+	return time.Time{}, false
+}
+
+// detectDateOrder looks for a numeric date whose first or second part is
+// over 12, which settles the order. Day first is assumed otherwise.
+func detectDateOrder(t *spreadsheet.Table, mapping map[string]int) DateOrder {
+	for _, field := range []string{FieldDate, FieldDeadline, FieldFollowUpAt} {
+		j, ok := mapping[field]
+		if !ok {
+			continue
+		}
+		for i := range t.Rows {
+			m := numericDateRe.FindStringSubmatch(t.Cell(i, j))
+			if m == nil {
+				continue
+			}
+			a, _ := strconv.Atoi(m[1])
+			b, _ := strconv.Atoi(m[2])
+			if a > 12 && b <= 12 {
+				return DayMonthYear
+			}
+			if b > 12 && a <= 12 {
+				return MonthDayYear
+			}
+		}
+	}
+	return DayMonthYear
+}
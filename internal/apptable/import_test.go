@@ -0,0 +1,60 @@
+package apptable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/spreadsheet"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in    string
+		order DateOrder
+		want  time.Time
+	}{
+		{"2025-03-04", DayMonthYear, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"2025-03-04 09:30", DayMonthYear, time.Date(2025, 3, 4, 9, 30, 0, 0, time.UTC)},
+		{"2025-03-04T09:30:00+02:00", DayMonthYear, time.Date(2025, 3, 4, 7, 30, 0, 0, time.UTC)},
+		{"2025-03-04T23:30:00-05:00", DayMonthYear, time.Date(2025, 3, 5, 4, 30, 0, 0, time.UTC)},
+		{"4 Mar 2025", DayMonthYear, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"March 4, 2025", MonthDayYear, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"03/04/2025", DayMonthYear, time.Date(2025, 4, 3, 0, 0, 0, 0, time.UTC)},
+		{"03/04/2025", MonthDayYear, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"3.4.25 14:05", DayMonthYear, time.Date(2025, 4, 3, 14, 5, 0, 0, time.UTC)},
+		{"45720", DayMonthYear, time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"45720.5", DayMonthYear, time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, ok := parseDate(tt.in, tt.order)
+		if !ok || got != tt.want {
+			t.Errorf("parseDate(%q, %s) = %v, %v, want %v", tt.in, tt.order, got, ok, tt.want)
+		}
+	}
+	for _, in := range []string{"", "soon", "31/02/2025", "12/31/2025 25:00", "42", "2025-13-01"} {
+		if got, ok := parseDate(in, DayMonthYear); ok {
+			t.Errorf("parseDate(%q) = %v, want no date", in, got)
+		}
+	}
+}
+
+func TestDetectDateOrder(t *testing.T) {
+	tests := []struct {
+		dates []string
+		want  DateOrder
+	}{
+		{[]string{"2025-03-04", "03/04/2025", "25/04/2025"}, DayMonthYear},
+		{[]string{"03/04/2025", "04/25/2025"}, MonthDayYear},
+		{[]string{"03/04/2025", "05/06/2025"}, DayMonthYear},
+		{nil, DayMonthYear},
+	}
+	for _, tt := range tests {
+		table := &spreadsheet.Table{Header: []string{"Company", "Applied"}}
+		for _, d := range tt.dates {
+			table.Rows = append(table.Rows, []string{"Acme", d})
+		}
+		if got := detectDateOrder(table, map[string]int{FieldDate: 1}); got != tt.want {
+			t.Errorf("detectDateOrder(%q) = %s, want %s", tt.dates, got, tt.want)
+		}
+	}
+}
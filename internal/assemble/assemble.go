@@ -0,0 +1,277 @@
+// Package assemble builds a tailored CV from a master CV by keeping only the
+// content tagged for a target role, within a length budget.
+package assemble
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// Layout assumptions used to estimate the length of a CV. They mirror the
+// one-column print layout closely enough for budgeting, not for typesetting.
+const (
+	linesPerPage   = 55
+	charsPerLine   = 95
+	headerLines    = 6 // name, title and contact details
+	sectionLines   = 2 // heading and spacing
+	entryLines     = 2 // title/company line and dates/location line
+	skillGroupLine = 1
+)
+
+// Options controls an assembly.
+type Options struct {
+	// Tags to keep, in priority order: earlier tags weigh more.
+	Tags []string
+	// MaxPages limits the estimated length. Zero means no limit.
+	MaxPages float64
+	// MaxEntries limits the number of experience entries. Zero means no limit.
+	MaxEntries int
+	// MaxBulletsPerEntry limits the bullets kept per entry. Zero means no limit.
+	MaxBulletsPerEntry int
+	// KeepUntagged keeps entries, bullets and skills that have no tags.
+	KeepUntagged bool
+}
+
+type scoredBullet struct {
+	bullet models.Bullet
+	score  int
+	index  int
+}
+
+type candidate struct {
+	entry   models.Experience
+	bullets []scoredBullet // matching bullets, best first
+	score   int
+	index   int
+}
+
+// Assemble returns a copy of data that keeps only the experience entries,
+// bullets and skills matching opts.Tags. Entries and bullets are chosen in
+// priority order until the budget is used up; entries keep their original
+// (chronological) order in the result, bullets are ordered by priority.
+// Personal details, summary, education, languages and certifications are
+// kept as they are. Entries and skill groups that lose content stop
+// following the library item they were taken from.
+func Assemble(data models.CVData, opts Options) (models.CVData, models.AssembleReport) {
+	weights := tagWeights(opts.Tags)
+	out := data
+	var report models.AssembleReport
+
+	// Skills: keep matching items and drop empty groups
+	out.Skills = nil
+	var keptGroups []int
+	for gi, g := range data.Skills {
+		kept := g
+		kept.Items = nil
+		for _, s := range g.Items {
+			score := score(s.Tags, weights)
+			if len(s.Tags) == 0 {
+				// Untagged skills match a tag with the same name
+				score = weights[normalizeTag(s.Name)]
+			}
+			if score > 0 || (opts.KeepUntagged && len(s.Tags) == 0) {
+				kept.Items = append(kept.Items, s)
+				report.KeptSkills++
+			} else {
+				report.DroppedSkills++
+			}
+		}
+		if len(kept.Items) > 0 {
+			if len(kept.Items) < len(g.Items) {
+				// Following the library item would bring the dropped skills back
+				kept.Ref = ""
+			}
+			out.Skills = append(out.Skills, kept)
+			keptGroups = append(keptGroups, gi)
+		}
+	}
+
+	// Experience: score entries and their bullets
+	var candidates []candidate
+	for i, exp := range data.Experience {
+		c := candidate{entry: exp, index: i, score: score(exp.Tags, weights)}
+		for j, b := range exp.Bullets {
+			tags := b.Tags
+			if len(tags) == 0 {
+				tags = exp.Tags
+			}
+			s := score(tags, weights)
+			if s > 0 || (opts.KeepUntagged && len(tags) == 0) {
+				c.bullets = append(c.bullets, scoredBullet{bullet: b, score: s, index: j})
+			} else {
+				report.DroppedBullets++
+			}
+			if s > c.score {
+				c.score = s
+			}
+		}
+		if c.score == 0 && !(opts.KeepUntagged && len(exp.Tags) == 0) {
+			report.DroppedEntries++
+			report.DroppedBullets += len(c.bullets)
+			continue
+		}
+		sort.SliceStable(c.bullets, func(a, b int) bool { return c.bullets[a].score > c.bullets[b].score })
+		if opts.MaxBulletsPerEntry > 0 && len(c.bullets) > opts.MaxBulletsPerEntry {
+			report.DroppedBullets += len(c.bullets) - opts.MaxBulletsPerEntry
+			c.bullets = c.bullets[:opts.MaxBulletsPerEntry]
+		}
+		candidates = append(candidates, c)
+	}
+	sort.SliceStable(candidates, func(a, b int) bool { return candidates[a].score > candidates[b].score })
+
+	// Greedily add entries by priority while they fit the budget
+	out.Experience = nil
+	lines := baseLines(out)
+	budget := int(opts.MaxPages * linesPerPage)
+	var chosen []candidate
+	for _, c := range candidates {
+		if opts.MaxEntries > 0 && len(chosen) >= opts.MaxEntries {
+			report.DroppedEntries++
+			report.DroppedBullets += len(c.bullets)
+			continue
+		}
+		cost := entryLines + textLines(c.entry.Description)
+		if len(chosen) == 0 {
+			cost += sectionLines
+		}
+		if budget > 0 && lines+cost > budget {
+			report.DroppedEntries++
+			report.DroppedBullets += len(c.bullets)
+			continue
+		}
+		// Drop the lowest priority bullets that do not fit
+		n := 0
+		for n < len(c.bullets) {
+			extra := textLines(c.bullets[n].bullet.Text)
+			if budget > 0 && lines+cost+extra > budget {
+				break
+			}
+			cost += extra
+			n++
+		}
+		report.DroppedBullets += len(c.bullets) - n
+		c.bullets = c.bullets[:n]
+		lines += cost
+		chosen = append(chosen, c)
+	}
+
+	sort.Slice(chosen, func(a, b int) bool { return chosen[a].index < chosen[b].index })
+	for _, c := range chosen {
+		exp := c.entry
+		exp.Bullets = nil
+		reordered := false
+		for k, b := range c.bullets {
+			exp.Bullets = append(exp.Bullets, b.bullet)
+			reordered = reordered || b.index != k
+		}
+		if reordered || len(exp.Bullets) < len(c.entry.Bullets) {
+			// Following the library item would bring the dropped bullets
+			// back and undo their order
+			exp.Ref = ""
+		}
+		report.KeptBullets += len(exp.Bullets)
+		out.Experience = append(out.Experience, exp)
+	}
+	report.KeptEntries = len(out.Experience)
+	report.EstimatedPages = float64(lines) / linesPerPage
+
+	// Translations are matched by index, so realign them with the kept entries
+	if len(data.Locales) > 0 {
+		out.Locales = realignLocales(data.Locales, chosen, keptGroups)
+	}
+	return out, report
+}
+
+// tagWeights gives the first tag the highest weight.
+func tagWeights(tags []string) map[string]int {
+	weights := map[string]int{}
+	for i, t := range tags {
+		t = normalizeTag(t)
+		if _, ok := weights[t]; !ok && t != "" {
+			weights[t] = len(tags) - i
+		}
+	}
+	return weights
+}
+
+func score(tags []string, weights map[string]int) int {
+	total := 0
+	for _, t := range tags {
+		total += weights[normalizeTag(t)]
+	}
+	return total
+}
+
+func normalizeTag(t string) string {
+	return strings.ToLower(strings.TrimSpace(t))
+}
+
+// baseLines estimates the lines used by everything except experience.
+func baseLines(d models.CVData) int {
+	lines := headerLines
+	if d.Summary != "" {
+		lines += sectionLines + textLines(d.Summary)
+	}
+	if len(d.Education) > 0 {
+		lines += sectionLines
+		for _, e := range d.Education {
+			lines += entryLines + textLines(e.Description)
+		}
+	}
+	if len(d.Skills) > 0 {
+		lines += sectionLines + len(d.Skills)*skillGroupLine
+	}
+	if len(d.Languages) > 0 {
+		lines += sectionLines + 1
+	}
+	if len(d.Certifications) > 0 {
+		lines += sectionLines + len(d.Certifications)
+	}
+	return lines
+}
+
+func textLines(s string) int {
+	if strings.TrimSpace(s) == "" {
+		return 0
+	}
+	lines := 0
+	for _, para := range strings.Split(s, "\n") {
+		lines += (len([]rune(para)) + charsPerLine - 1) / charsPerLine
+		if para == "" {
+			lines++
+		}
+	}
+	return lines
+}
+
+// realignLocales keeps the translations of the kept experience entries and
+// skill groups, since translations are matched to entries by index.
+func realignLocales(locales map[string]models.LocalizedContent, chosen []candidate, keptGroups []int) map[string]models.LocalizedContent {
+	out := make(map[string]models.LocalizedContent, len(locales))
+	for key, loc := range locales {
+		exps := loc.Experience
+		loc.Experience = nil
+		for _, c := range chosen {
+			if c.index < len(exps) {
+				loc.Experience = append(loc.Experience, exps[c.index])
+			} else {
+				loc.Experience = append(loc.Experience, models.LocalizedExperience{})
+			}
+		}
+		cats := loc.SkillCategories
+		loc.SkillCategories = nil
+		if len(cats) > 0 {
+			for _, gi := range keptGroups {
+				if gi < len(cats) {
+					loc.SkillCategories = append(loc.SkillCategories, cats[gi])
+				} else {
+					loc.SkillCategories = append(loc.SkillCategories, "")
+				}
+			}
+		}
+		out[key] = loc
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package assemble
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// TestAssembleKeepsTrimmedLibraryContent saves an assembled CV the way an
+// update does, resolving its library references, and checks that nothing
+// dropped comes back.
+func TestAssembleKeepsTrimmedLibraryContent(t *testing.T) {
+	backend := models.Experience{Company: "Acme", Title: "Engineer", Tags: []string{"backend"}, Bullets: []models.Bullet{
+		{Text: "Designed the billing API"},
+		{Text: "Ran design reviews", Tags: []string{"frontend"}},
+		{Text: "Moved search to Go", Tags: []string{"go"}},
+	}}
+	whole := models.Experience{Company: "Initech", Title: "Intern", Tags: []string{"backend"}, Bullets: []models.Bullet{
+		{Text: "Wrote SQL reports"},
+	}}
+	languages := models.SkillGroup{Category: "Languages", Items: []models.Skill{
+		{Name: "Go"}, {Name: "TypeScript", Tags: []string{"frontend"}},
+	}}
+	tools := models.SkillGroup{Category: "Tools", Items: []models.Skill{
+		{Name: "Postgres", Tags: []string{"backend"}},
+	}}
+	items := map[string]models.LibraryItem{
+		"exp-acme":    {ID: "exp-acme", Kind: models.LibraryExperience, Content: models.LibraryContent{Experience: &backend}},
+		"exp-initech": {ID: "exp-initech", Kind: models.LibraryExperience, Content: models.LibraryContent{Experience: &whole}},
+		"sk-lang":     {ID: "sk-lang", Kind: models.LibrarySkills, Content: models.LibraryContent{Skills: &languages}},
+		"sk-tools":    {ID: "sk-tools", Kind: models.LibrarySkills, Content: models.LibraryContent{Skills: &tools}},
+	}
+	data := models.CVData{}
+	for _, id := range []string{"exp-acme", "exp-initech"} {
+		exp := *items[id].Content.Experience
+		exp.Ref = id
+		data.Experience = append(data.Experience, exp)
+	}
+	for _, id := range []string{"sk-lang", "sk-tools"} {
+		g := *items[id].Content.Skills
+		g.Ref = id
+		data.Skills = append(data.Skills, g)
+	}
+
+	out, _ := Assemble(data, Options{Tags: []string{"go", "backend"}})
+	saved := out
+	saved.Experience = append([]models.Experience(nil), out.Experience...)
+	saved.Skills = append([]models.SkillGroup(nil), out.Skills...)
+	if missing := models.ApplyLibrary(&saved, items); len(missing) > 0 {
+		t.Fatalf("ApplyLibrary() missing %v", missing)
+	}
+	if !reflect.DeepEqual(saved, out) {
+		t.Errorf("saving brought back trimmed content:\n got %+v\nwant %+v", saved, out)
+	}
+
+	// Only what was trimmed or reordered stops following the library
+	var refs []string
+	for _, exp := range out.Experience {
+		refs = append(refs, exp.Ref)
+	}
+	for _, g := range out.Skills {
+		refs = append(refs, g.Ref)
+	}
+	if want := []string{"", "exp-initech", "", "sk-tools"}; !reflect.DeepEqual(refs, want) {
+		t.Errorf("refs = %q, want %q", refs, want)
+	}
+}
@@ -0,0 +1,200 @@
+// Package coverletter fills cover letter templates with the details of an
+// application and a CV. Templates use Go's text/template syntax, e.g.
+// "Dear {{.Company}} team, I am applying for the {{.Role}} position."
+package coverletter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// maxOutput caps the size of a rendered letter.
+const maxOutput = 100 << 10
+
+// Fields are what a template can refer to.
+type Fields struct {
+	Company     string
+	Role        string
+	Location    string
+	URL         string
+	Source      string
+	Date        string // Application date, e.g. "2 January 2006"
+	Today       string
+	Name        string // First and last name from the CV
+	Personal    models.PersonalInfo
+	Summary     string
+	CV          models.CVData
+	Application models.Application
+}
+
+// NewFields collects the fields for an application and, optionally, the
+// CV sent with it.
+func NewFields(app models.Application, cv *models.CVData, now time.Time) Fields {
+	f := Fields{
+		Company:     app.Company,
+		Role:        app.Role,
+		Location:    app.Location,
+		URL:         app.URL,
+		Source:      app.Source,
+		Date:        formatDate(app.Date),
+		Today:       formatDate(now),
+		Application: app,
+	}
+	if cv != nil {
+		f.CV = *cv
+		f.Personal = cv.Personal
+		f.Summary = cv.Summary
+		f.Name = strings.TrimSpace(cv.Personal.FirstName + " " + cv.Personal.LastName)
+	}
+	return f
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2 January 2006")
+}
+
+// Parse parses a template. Besides syntax errors, it rejects what could
+// keep the server busy: range loops over anything but a field, such as
+// {{range 1000000000}}, and {{define}}, {{block}} and {{template}}, whose
+// calls can fan out exponentially while writing nothing.
+func Parse(text string) (*template.Template, error) {
+	t, err := template.New("letter").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, cleanError(err)
+	}
+	if len(t.Templates()) > 1 {
+		return nil, errors.New("define and block are not supported")
+	}
+	if t.Tree != nil {
+		if err := checkNodes(t.Root); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func checkNodes(n parse.Node) error {
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Nodes {
+			if err := checkNodes(c); err != nil {
+				return err
+			}
+		}
+	case *parse.TemplateNode:
+		return fmt.Errorf("line %d: template is not supported", n.Line)
+	case *parse.RangeNode:
+		if !rangesOverField(n.Pipe) {
+			return fmt.Errorf("line %d: range must loop over a field, such as .CV.Experience", n.Line)
+		}
+		return checkBranch(&n.BranchNode)
+	case *parse.IfNode:
+		return checkBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return checkBranch(&n.BranchNode)
+	}
+	return nil
+}
+
+func checkBranch(b *parse.BranchNode) error {
+	if err := checkNodes(b.List); err != nil {
+		return err
+	}
+	if b.ElseList != nil {
+		return checkNodes(b.ElseList)
+	}
+	return nil
+}
+
+func rangesOverField(p *parse.PipeNode) bool {
+	if p == nil || len(p.Cmds) != 1 || len(p.Cmds[0].Args) != 1 {
+		return false
+	}
+	switch arg := p.Cmds[0].Args[0].(type) {
+	case *parse.FieldNode:
+		return true
+	case *parse.VariableNode:
+		// $.CV.Experience or $x.Items, not a bare variable
+		return len(arg.Ident) > 1
+	}
+	return false
+}
+
+// Validate checks that a template parses and only refers to known fields,
+// by rendering it with sample data.
+func Validate(text string) error {
+	t, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	return execute(t, sampleFields())
+}
+
+// Render fills a template with the given fields.
+func Render(text string, f Fields) (string, error) {
+	t, err := Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b limitedBuilder
+	if err := t.Execute(&b, f); err != nil {
+		return "", cleanError(err)
+	}
+	return b.String(), nil
+}
+
+func execute(t *template.Template, f Fields) error {
+	var b limitedBuilder
+	return cleanError(t.Execute(&b, f))
+}
+
+// sampleFields has one of everything, so templates that index into lists
+// validate.
+func sampleFields() Fields {
+	cv := models.CVData{
+		Experience:     []models.Experience{{Bullets: []models.Bullet{{}}, Tags: []string{""}}},
+		Education:      []models.Education{{}},
+		Skills:         []models.SkillGroup{{Items: []models.Skill{{Tags: []string{""}}}}},
+		Languages:      []models.Language{{}},
+		Certifications: []models.Certification{{}},
+	}
+	return NewFields(models.Application{}, &cv, time.Time{})
+}
+
+var errTooLong = fmt.Errorf("letter is longer than %d KB", maxOutput>>10)
+
+type limitedBuilder struct{ strings.Builder }
+
+func (b *limitedBuilder) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > maxOutput {
+		return 0, errTooLong
+	}
+	return b.Builder.Write(p)
+}
+
+// cleanError drops the template name from errors, which means nothing to
+// the user: "template: letter:1:2: executing ..." becomes "1:2: ...".
+func cleanError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, errTooLong) {
+		return errTooLong
+	}
+	msg := strings.TrimPrefix(err.Error(), "template: ")
+	msg = strings.TrimPrefix(msg, "letter:")
+	msg = strings.Replace(msg, `executing "letter" at `, "", 1)
+	return errors.New(msg)
+}
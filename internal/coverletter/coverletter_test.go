@@ -0,0 +1,76 @@
+package coverletter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+func TestRender(t *testing.T) {
+	app := models.Application{Company: "Acme", Role: "Engineer", Date: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)}
+	cv := models.CVData{
+		Personal:   models.PersonalInfo{FirstName: "Ann", LastName: "Lee"},
+		Experience: []models.Experience{{Company: "Initech"}, {Company: "Globex"}},
+	}
+	text := `Dear {{.Company}} team, I applied for {{.Role}} on {{.Date}}.{{range .CV.Experience}} {{.Company}}{{end}} – {{.Name}}`
+	got, err := Render(text, NewFields(app, &cv, time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Dear Acme team, I applied for Engineer on 4 March 2026. Initech Globex – Ann Lee"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := []string{
+		"Dear {{.Company}} team",
+		"{{range $i, $e := .CV.Experience}}{{range $e.Bullets}}{{.Text}}{{end}}{{end}}",
+		"{{with .Personal}}{{.Email}}{{else}}none{{end}}",
+	}
+	for _, text := range valid {
+		if err := Validate(text); err != nil {
+			t.Errorf("Validate(%q) = %v", text, err)
+		}
+	}
+
+	invalid := []string{
+		"{{.Compnay}}",
+		"{{range 1000000000}}x{{end}}",
+		"{{if .Role}}{{else}}{{range 10}}x{{end}}{{end}}",
+		`{{define "a"}}x{{end}}{{template "a"}}`,
+		`{{block "a" .}}x{{end}}`,
+		`{{define "letter"}}{{template "letter" .}}{{end}}`,
+		"{{.Company",
+	}
+	for _, text := range invalid {
+		if err := Validate(text); err == nil {
+			t.Errorf("Validate(%q) succeeded, want an error", text)
+		}
+	}
+}
+
+// TestValidateTemplateChain makes sure a chain of templates each calling
+// the next twice, which runs in exponential time without writing
+// anything, is refused rather than run.
+func TestValidateTemplateChain(t *testing.T) {
+	var b strings.Builder
+	for c := 'a'; c < 'z'; c++ {
+		fmt.Fprintf(&b, `{{define "%c"}}{{template "%c" .}}{{template "%c" .}}{{end}}`, c, c+1, c+1)
+	}
+	b.WriteString(`{{define "z"}}{{end}}{{template "a" .}}`)
+
+	done := make(chan error, 1)
+	go func() { done <- Validate(b.String()) }()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Validate() of a template chain succeeded, want an error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Validate() of a template chain is still running")
+	}
+}
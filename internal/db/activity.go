@@ -0,0 +1,242 @@
+package db
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// ActivityReport gathers the user's job search activity from the day from
+// to the day to, inclusive. Outcomes are taken from the status history as
+// of the end of the period, so a report for a past month reads the same
+// later on.
+func (db *DB) ActivityReport(userID string, from, to, now time.Time) (*models.ActivityReport, error) {
+	user, err := db.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	apps, err := db.ListApplications(userID)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := db.GetPipeline(userID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := db.activityEvents(userID)
+	if err != nil {
+		return nil, err
+	}
+	interviews, err := db.queryInterviews(
+		`SELECT `+interviewColumns+`
+		FROM interviews i
+		JOIN applications a ON a.id = i.application_id
+		WHERE (a.user_id = ? OR a.user_id IS NULL) AND i.outcome != ?
+		ORDER BY i.starts_at`,
+		userID, models.OutcomeCancelled,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	first, last := day(from), day(to)
+	inPeriod := func(t time.Time) bool {
+		d := day(t)
+		return d >= first && d <= last
+	}
+	r := &models.ActivityReport{
+		From:         from,
+		To:           to,
+		GeneratedAt:  now,
+		Applications: []models.ActivityApplication{},
+		Activities:   []models.Activity{},
+	}
+	if user != nil {
+		r.Name, r.Email = user.Name, user.Email
+	}
+
+	byID := map[string]models.Application{}
+	for _, app := range apps {
+		byID[app.ID] = app
+	}
+	// An interview both scheduled and logged is counted once, from the
+	// schedule, which says more about it
+	scheduled := map[string]int{} // Application ID and day to activity index
+	for _, iv := range interviews {
+		if !inPeriod(iv.StartsAt) {
+			continue
+		}
+		desc := "Interview (" + strings.ReplaceAll(string(iv.Type), "_", " ") + ")"
+		if iv.Location != "" {
+			desc += " at " + iv.Location
+		}
+		scheduled[iv.ApplicationID+" "+day(iv.StartsAt)] = len(r.Activities)
+		r.Activities = append(r.Activities, models.Activity{
+			ApplicationID: iv.ApplicationID, Date: iv.StartsAt, Company: iv.Company, Role: iv.Role,
+			Type: models.EventInterview, Description: desc,
+		})
+		r.Summary.Interviews++
+	}
+	// Status of each application at the end of the period: the first one
+	// recorded, then each change up to then
+	status := map[string]models.ApplicationStatus{}
+	for _, e := range events {
+		app, ok := byID[e.ApplicationID]
+		if !ok {
+			continue
+		}
+		switch e.Type {
+		case models.EventCreated, models.EventStatusChange:
+			if _, seen := status[app.ID]; !seen || day(e.OccurredAt) <= last {
+				status[app.ID] = models.ApplicationStatus(e.To)
+			}
+		}
+		if !inPeriod(e.OccurredAt) {
+			continue
+		}
+		a := models.Activity{ApplicationID: app.ID, Date: e.OccurredAt, Company: app.Company, Role: app.Role, Type: e.Type}
+		switch e.Type {
+		case models.EventCall:
+			a.Description = "Phone call"
+			r.Summary.Contacts++
+		case models.EventEmail:
+			a.Description = "Email"
+			r.Summary.Contacts++
+		case models.EventInterview:
+			if i, ok := scheduled[app.ID+" "+day(e.OccurredAt)]; ok {
+				if e.Note != "" {
+					r.Activities[i].Description += ": " + e.Note
+				}
+				continue
+			}
+			a.Description = "Interview"
+			r.Summary.Interviews++
+		case models.EventStatusChange:
+			a.Description = "Status changed to " + e.To
+		default:
+			continue
+		}
+		if e.Note != "" {
+			a.Description += ": " + e.Note
+		}
+		r.Activities = append(r.Activities, a)
+	}
+	sort.SliceStable(r.Activities, func(i, j int) bool {
+		return r.Activities[i].Date.Before(r.Activities[j].Date)
+	})
+
+	companies := map[string]bool{}
+	counts := map[models.ApplicationStatus]int{}
+	weeks := map[string]int{}
+	for _, app := range apps {
+		if !inPeriod(app.Date) {
+			continue
+		}
+		st, ok := status[app.ID]
+		if !ok {
+			st = app.Status
+		}
+		a := models.ActivityApplication{
+			ApplicationID: app.ID,
+			Date:          app.Date,
+			Company:       app.Company,
+			Role:          app.Role,
+			Location:      app.Location,
+			URL:           app.URL,
+			ContactMethod: contactMethod(app),
+			Outcome:       st,
+		}
+		if stage := models.FindStage(pipeline, st); stage != nil {
+			a.Category = stage.Category
+		}
+		r.Applications = append(r.Applications, a)
+		companies[models.CompanyKey(app.Company)] = true
+		counts[st]++
+		weeks[monday(app.Date).Format("2006-01-02")]++
+	}
+	sort.SliceStable(r.Applications, func(i, j int) bool {
+		return r.Applications[i].Date.Before(r.Applications[j].Date)
+	})
+
+	r.Summary.Applications = len(r.Applications)
+	r.Summary.Companies = len(companies)
+	r.Summary.Outcomes = []models.OutcomeCount{}
+	for _, st := range pipeline {
+		if n := counts[models.ApplicationStatus(st.Name)]; n > 0 {
+			r.Summary.Outcomes = append(r.Summary.Outcomes, models.OutcomeCount{Status: models.ApplicationStatus(st.Name), Category: st.Category, Count: n})
+			delete(counts, models.ApplicationStatus(st.Name))
+		}
+	}
+	// Statuses of stages since removed from the pipeline
+	for st, n := range counts {
+		r.Summary.Outcomes = append(r.Summary.Outcomes, models.OutcomeCount{Status: st, Count: n})
+	}
+	r.Summary.PerWeek = []models.WeekCount{}
+	for w := monday(from); day(w) <= last; w = w.AddDate(0, 0, 7) {
+		key := w.Format("2006-01-02")
+		r.Summary.PerWeek = append(r.Summary.PerWeek, models.WeekCount{Week: key, Count: weeks[key]})
+	}
+	return r, nil
+}
+
+// activityEvents returns the timeline events of all the user's
+// applications, oldest first.
+func (db *DB) activityEvents(userID string) ([]models.ApplicationEvent, error) {
+	rows, err := db.conn.Query(
+		`SELECT e.id, e.application_id, e.type, e.field, e.from_value, e.to_value, e.note, e.occurred_at, e.created_at
+		FROM application_events e
+		JOIN applications a ON a.id = e.application_id
+		WHERE a.user_id = ? OR a.user_id IS NULL`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []models.ApplicationEvent
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].OccurredAt.Equal(events[j].OccurredAt) {
+			return events[i].CreatedAt.Before(events[j].CreatedAt)
+		}
+		return events[i].OccurredAt.Before(events[j].OccurredAt)
+	})
+	return events, nil
+}
+
+// contactMethod says how an application was made: its source, or failing
+// that the site of its posting.
+func contactMethod(app models.Application) string {
+	if app.Source != "" {
+		return app.Source
+	}
+	if u, err := url.Parse(app.URL); err == nil && u.Hostname() != "" {
+		return strings.TrimPrefix(u.Hostname(), "www.")
+	}
+	return ""
+}
+
+// day returns the calendar date of t as YYYY-MM-DD, which compares in
+// order as a string.
+func day(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// monday returns the Monday starting the week of t.
+func monday(t time.Time) time.Time {
+	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return d.AddDate(0, 0, -((int(d.Weekday()) + 6) % 7))
+}
@@ -0,0 +1,54 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+func TestActivityReportInterviewScheduledAndLogged(t *testing.T) {
+	d, user := newTestDB(t)
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	appID := seedApplication(t, d, user.ID, "Acme", from.AddDate(0, 0, 2))
+
+	at := time.Date(2024, 3, 10, 14, 0, 0, 0, time.UTC)
+	_, err := d.CreateInterview(appID, user.ID, models.InterviewRequest{
+		Type: models.InterviewTechnical, StartsAt: at, EndsAt: at.Add(time.Hour), Location: "Berlin",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logged := at.Add(2 * time.Hour)
+	if _, err := d.CreateEvent(appID, user.ID, models.CreateEventRequest{
+		Type: models.EventInterview, Note: "went well", OccurredAt: &logged,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// A logged interview on another day is a different one
+	other := at.AddDate(0, 0, 7)
+	if _, err := d.CreateEvent(appID, user.ID, models.CreateEventRequest{
+		Type: models.EventInterview, OccurredAt: &other,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := d.ActivityReport(user.ID, from, to, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Summary.Interviews != 2 {
+		t.Errorf("Summary.Interviews = %d, want 2", r.Summary.Interviews)
+	}
+	var got []string
+	for _, a := range r.Activities {
+		if a.Type == models.EventInterview {
+			got = append(got, a.Description)
+		}
+	}
+	want := []string{"Interview (technical) at Berlin: went well", "Interview"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("interview activities = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,378 @@
+package db
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// analyticsScope limits the analytics queries to a user's applications and
+// pipeline. It takes the user ID twice.
+const analyticsScope = `
+	WITH owned AS (SELECT * FROM applications WHERE user_id = ? OR user_id IS NULL),
+	stages AS (SELECT name, category, terminal FROM pipeline_stages WHERE user_id = ?)`
+
+// sqlTime turns a stored timestamp into something SQLite date functions
+// understand. Timestamps are stored in Go's format ("2006-01-02 15:04:05
+// +0000 UTC"), so the zone suffix is dropped; all but user-entered dates
+// are in UTC.
+func sqlTime(column string) string {
+	return "substr(" + column + ", 1, 19)"
+}
+
+// ApplicationAnalytics computes funnel, response time, weekly volume and
+// outcome statistics over the user's applications and their status
+// history. Open applications without activity for staleAfter are stale.
+func (db *DB) ApplicationAnalytics(userID string, now time.Time, staleAfter time.Duration) (*models.ApplicationAnalytics, error) {
+	pipeline, err := db.GetPipeline(userID)
+	if err != nil {
+		return nil, err
+	}
+	a := &models.ApplicationAnalytics{StaleAfterDays: int(staleAfter.Hours() / 24)}
+
+	if err := db.analyticsStages(userID, pipeline, a); err != nil {
+		return nil, err
+	}
+	if err := db.analyticsFunnel(userID, a); err != nil {
+		return nil, err
+	}
+	if err := db.analyticsResponseTime(userID, a); err != nil {
+		return nil, err
+	}
+	if err := db.analyticsPerWeek(userID, now, a); err != nil {
+		return nil, err
+	}
+	a.ByCompany, err = db.analyticsBreakdown(userID, `company`)
+	if err != nil {
+		return nil, err
+	}
+	a.BySource, err = db.analyticsBreakdown(userID, `trim(source)`)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := now.UTC().Add(-staleAfter).Format("2006-01-02 15:04:05")
+	err = db.conn.QueryRow(analyticsScope+`
+		SELECT COUNT(*) FROM owned a
+		LEFT JOIN stages s ON s.name = a.status
+		WHERE NOT COALESCE(s.terminal, 0)
+		AND max(`+sqlTime("a.updated_at")+`, COALESCE(
+			(SELECT max(`+sqlTime("e.occurred_at")+`) FROM application_events e WHERE e.application_id = a.id), '')) < ?`,
+		userID, userID, cutoff,
+	).Scan(&a.Stale)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// visitedStages lists each application with every stage it has been in,
+// from its status history and its current status.
+const visitedStages = `,
+	visited AS (
+		SELECT id AS application_id, status AS stage FROM owned
+		UNION
+		SELECT e.application_id, e.to_value FROM application_events e
+		JOIN owned o ON o.id = e.application_id
+		WHERE e.type IN ('created', 'status_change')
+	)`
+
+// furthestStage ranks how far each application got: 1 applied, 2 interview,
+// 3 offer. Rejections and closures do not undo the progress made before.
+const furthestStage = visitedStages + `,
+	furthest AS (
+		SELECT v.application_id, MAX(CASE s.category WHEN 'interview' THEN 2 WHEN 'offer' THEN 3 ELSE 1 END) AS furthest
+		FROM visited v LEFT JOIN stages s ON s.name = v.stage
+		GROUP BY v.application_id
+	)`
+
+func (db *DB) analyticsStages(userID string, pipeline []models.PipelineStage, a *models.ApplicationAnalytics) error {
+	a.Stages = make([]models.StageCount, len(pipeline))
+	counts := map[string]*models.StageCount{}
+	for i, st := range pipeline {
+		a.Stages[i] = models.StageCount{Stage: st.Name, Category: st.Category}
+		counts[st.Name] = &a.Stages[i]
+	}
+
+	rows, err := db.conn.Query(analyticsScope+visitedStages+`
+		SELECT v.stage, COUNT(DISTINCT v.application_id),
+			(SELECT COUNT(*) FROM owned o WHERE o.status = v.stage)
+		FROM visited v GROUP BY v.stage`,
+		userID, userID,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var stage string
+		var reached, current int
+		if err := rows.Scan(&stage, &reached, &current); err != nil {
+			return err
+		}
+		// Stages deleted from the pipeline only live on in history
+		if sc := counts[stage]; sc != nil {
+			sc.Reached = reached
+			sc.Current = current
+		}
+	}
+	return rows.Err()
+}
+
+func (db *DB) analyticsFunnel(userID string, a *models.ApplicationAnalytics) error {
+	var applied, interview, offer int
+	err := db.conn.QueryRow(analyticsScope+furthestStage+`
+		SELECT COUNT(*), COALESCE(SUM(furthest >= 2), 0), COALESCE(SUM(furthest >= 3), 0) FROM furthest`,
+		userID, userID,
+	).Scan(&applied, &interview, &offer)
+	if err != nil {
+		return err
+	}
+	a.Total = applied
+	a.Funnel = []models.FunnelStep{
+		{Category: models.CategoryApplied, Reached: applied, Conversion: 1},
+		{Category: models.CategoryInterview, Reached: interview, Conversion: ratio(interview, applied)},
+		{Category: models.CategoryOffer, Reached: offer, Conversion: ratio(offer, interview)},
+	}
+	return nil
+}
+
+// analyticsResponseTime measures the days from applying to the first move
+// into a stage past "applied", be it an interview or a rejection.
+func (db *DB) analyticsResponseTime(userID string, a *models.ApplicationAnalytics) error {
+	rows, err := db.conn.Query(analyticsScope+`
+		SELECT julianday(min(`+sqlTime("e.occurred_at")+`)) - julianday(`+sqlTime("a.date")+`)
+		FROM owned a
+		JOIN application_events e ON e.application_id = a.id AND e.type = 'status_change'
+		JOIN stages s ON s.name = e.to_value AND s.category != 'applied'
+		GROUP BY a.id`,
+		userID, userID,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var days []float64
+	for rows.Next() {
+		var d *float64
+		if err := rows.Scan(&d); err != nil {
+			return err
+		}
+		if d == nil {
+			continue
+		}
+		days = append(days, math.Max(*d, 0))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	a.Responded = len(days)
+	if len(days) > 0 {
+		sort.Float64s(days)
+		m := days[len(days)/2]
+		if len(days)%2 == 0 {
+			m = (days[len(days)/2-1] + m) / 2
+		}
+		m = math.Round(m*10) / 10
+		a.MedianDaysToResponse = &m
+	}
+	return nil
+}
+
+// analyticsPerWeek counts applications by the week they were sent, with
+// empty weeks up to the current one filled in.
+func (db *DB) analyticsPerWeek(userID string, now time.Time, a *models.ApplicationAnalytics) error {
+	rows, err := db.conn.Query(analyticsScope+`
+		SELECT date(`+sqlTime("date")+`, 'weekday 0', '-6 days') AS week, COUNT(*)
+		FROM owned WHERE week IS NOT NULL GROUP BY week ORDER BY week`,
+		userID, userID,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	var first, last string
+	for rows.Next() {
+		var week string
+		var n int
+		if err := rows.Scan(&week, &n); err != nil {
+			return err
+		}
+		if first == "" {
+			first = week
+		}
+		last = week
+		counts[week] = n
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	a.PerWeek = []models.WeekCount{}
+	if first == "" {
+		return nil
+	}
+	start, _ := time.Parse("2006-01-02", first)
+	stop, _ := time.Parse("2006-01-02", last)
+	today := now.UTC().Truncate(24 * time.Hour)
+	if monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7)); monday.After(stop) {
+		stop = monday
+	}
+	for w := start; !w.After(stop); w = w.AddDate(0, 0, 7) {
+		key := w.Format("2006-01-02")
+		a.PerWeek = append(a.PerWeek, models.WeekCount{Week: key, Count: counts[key]})
+	}
+	return nil
+}
+
+// analyticsBreakdown counts applications by key and current stage category.
+// Keys are compared case-insensitively.
+func (db *DB) analyticsBreakdown(userID, key string) ([]models.OutcomeBreakdown, error) {
+	rows, err := db.conn.Query(analyticsScope+`
+		SELECT min(`+key+`), COALESCE(s.category, ''), COUNT(*)
+		FROM owned a LEFT JOIN stages s ON s.name = a.status
+		GROUP BY lower(`+key+`), s.category`,
+		userID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byKey := map[string]*models.OutcomeBreakdown{}
+	var order []string
+	for rows.Next() {
+		var k, category string
+		var n int
+		if err := rows.Scan(&k, &category, &n); err != nil {
+			return nil, err
+		}
+		b := byKey[lowerASCII(k)]
+		if b == nil {
+			b = &models.OutcomeBreakdown{Key: k}
+			byKey[lowerASCII(k)] = b
+			order = append(order, lowerASCII(k))
+		}
+		b.Add(models.StageCategory(category), n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	out := make([]models.OutcomeBreakdown, 0, len(order))
+	for _, k := range order {
+		out = append(out, *byKey[k])
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Total != out[j].Total {
+			return out[i].Total > out[j].Total
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out, nil
+}
+
+// lowerASCII lower-cases like SQLite's lower(), which leaves non-ASCII
+// letters alone.
+func lowerASCII(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if 'A' <= c && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return math.Round(float64(n)/float64(d)*1000) / 1000
+}
+
+// CVEffectiveness reports, per CV and per CV version, how many applications
+// used it and how many of those reached an interview or an offer. Each CV
+// is compared with the other CVs, and each version with the other versions
+// of its CV.
+func (db *DB) CVEffectiveness(userID string) (*models.CVEffectiveness, error) {
+	if _, err := db.GetPipeline(userID); err != nil {
+		return nil, err
+	}
+	rows, err := db.conn.Query(analyticsScope+furthestStage+`
+		SELECT a.cv_id, c.title, a.cv_version_id, COALESCE(cvv.message, ''), cvv.created_at,
+			COUNT(*), SUM(f.furthest >= 2), SUM(f.furthest >= 3)
+		FROM owned a
+		JOIN furthest f ON f.application_id = a.id
+		JOIN cvs c ON c.id = a.cv_id
+		LEFT JOIN cv_versions cvv ON cvv.id = a.cv_version_id
+		GROUP BY a.cv_id, a.cv_version_id`,
+		userID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cvs []*models.CVPerformance
+	byID := map[string]*models.CVPerformance{}
+	var applications, interviews, offers int
+	for rows.Next() {
+		var cvID, title, message string
+		var versionID, createdAt *string
+		var n, reachedInterview, reachedOffer int
+		if err := rows.Scan(&cvID, &title, &versionID, &message, &createdAt, &n, &reachedInterview, &reachedOffer); err != nil {
+			return nil, err
+		}
+		cv := byID[cvID]
+		if cv == nil {
+			cv = &models.CVPerformance{CVID: cvID, Title: title}
+			byID[cvID] = cv
+			cvs = append(cvs, cv)
+		}
+		cv.Versions = append(cv.Versions, models.CVVersionPerformance{
+			VersionID: versionID,
+			Message:   message,
+			CreatedAt: parseTimePtr(createdAt),
+			Outcomes:  models.NewOutcomeRates(n, reachedInterview, reachedOffer),
+		})
+		cv.Outcomes = models.NewOutcomeRates(cv.Outcomes.Applications+n,
+			cv.Outcomes.Interviews+reachedInterview, cv.Outcomes.Offers+reachedOffer)
+		applications += n
+		interviews += reachedInterview
+		offers += reachedOffer
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	report := &models.CVEffectiveness{
+		Overall: models.NewOutcomeRates(applications, interviews, offers),
+		CVs:     []models.CVPerformance{},
+	}
+	for _, cv := range cvs {
+		cv.Significance = models.CompareInterviewRates(cv.Outcomes, report.Overall.Without(cv.Outcomes))
+		for i := range cv.Versions {
+			v := &cv.Versions[i]
+			v.Significance = models.CompareInterviewRates(v.Outcomes, cv.Outcomes.Without(v.Outcomes))
+		}
+		// Oldest version first; the live CV last
+		sort.SliceStable(cv.Versions, func(i, j int) bool {
+			a, b := cv.Versions[i].CreatedAt, cv.Versions[j].CreatedAt
+			if a == nil || b == nil {
+				return b == nil && a != nil
+			}
+			return a.Before(*b)
+		})
+		report.CVs = append(report.CVs, *cv)
+	}
+	sort.SliceStable(report.CVs, func(i, j int) bool {
+		return report.CVs[i].Outcomes.Applications > report.CVs[j].Outcomes.Applications
+	})
+	return report, nil
+}
@@ -0,0 +1,201 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/google/uuid"
+)
+
+// statusMove is a status change at a given time.
+type statusMove struct {
+	status string
+	at     time.Time
+}
+
+// seedApplication stores an application sent on applied that then went
+// through moves, with its timeline dated accordingly rather than now.
+func seedApplication(t *testing.T, d *DB, userID, company string, applied time.Time, moves ...statusMove) string {
+	t.Helper()
+	app, err := d.CreateApplication(userID, models.CreateApplicationRequest{
+		Company: company, Role: "Engineer", Status: "Applied", Date: applied,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exec := func(query string, args ...any) {
+		t.Helper()
+		if _, err := d.conn.Exec(query, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exec(`UPDATE application_events SET occurred_at = ? WHERE application_id = ?`, applied, app.ID)
+	status, last := "Applied", applied
+	for _, m := range moves {
+		err := insertEvent(d.conn, models.ApplicationEvent{
+			ID: uuid.New().String(), ApplicationID: app.ID, Type: models.EventStatusChange,
+			Field: "status", From: status, To: m.status, OccurredAt: m.at, CreatedAt: m.at,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		status, last = m.status, m.at
+	}
+	exec(`UPDATE applications SET status = ?, created_at = ?, updated_at = ? WHERE id = ?`, status, applied, last, app.ID)
+	return app.ID
+}
+
+func TestApplicationAnalytics(t *testing.T) {
+	d, u := newTestDB(t)
+	day := func(month time.Month, n int) time.Time { return time.Date(2026, month, n, 0, 0, 0, 0, time.UTC) }
+	now := day(time.June, 15).Add(12 * time.Hour) // a Monday
+
+	seedApplication(t, d, u.ID, "Acme", day(time.June, 1),
+		statusMove{"Interviewing", day(time.June, 5)}, statusMove{"Offer", day(time.June, 10)})
+	seedApplication(t, d, u.ID, "acme", day(time.June, 2),
+		statusMove{"Rejected", day(time.June, 12)})
+	seedApplication(t, d, u.ID, "Globex", day(time.June, 3),
+		statusMove{"Interviewing", day(time.June, 5)}, statusMove{"Rejected", day(time.June, 8)})
+	seedApplication(t, d, u.ID, "Initech", day(time.May, 1))
+	seedApplication(t, d, u.ID, "Umbrella", day(time.June, 10))
+
+	a, err := d.ApplicationAnalytics(u.ID, now, 14*24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantFunnel := []models.FunnelStep{
+		{Category: models.CategoryApplied, Reached: 5, Conversion: 1},
+		{Category: models.CategoryInterview, Reached: 2, Conversion: 0.4},
+		{Category: models.CategoryOffer, Reached: 1, Conversion: 0.5},
+	}
+	if a.Total != 5 || !reflect.DeepEqual(a.Funnel, wantFunnel) {
+		t.Errorf("Total %d, Funnel %+v, want 5, %+v", a.Total, a.Funnel, wantFunnel)
+	}
+
+	wantStages := []models.StageCount{
+		{Stage: "Applied", Category: models.CategoryApplied, Current: 2, Reached: 5},
+		{Stage: "Interviewing", Category: models.CategoryInterview, Current: 0, Reached: 2},
+		{Stage: "Offer", Category: models.CategoryOffer, Current: 1, Reached: 1},
+		{Stage: "Rejected", Category: models.CategoryRejected, Current: 2, Reached: 2},
+	}
+	if !reflect.DeepEqual(a.Stages, wantStages) {
+		t.Errorf("Stages = %+v, want %+v", a.Stages, wantStages)
+	}
+
+	// Responses came after 4, 10 and 2 days
+	if a.Responded != 3 || a.MedianDaysToResponse == nil || *a.MedianDaysToResponse != 4 {
+		t.Errorf("Responded %d, MedianDaysToResponse %v, want 3, 4", a.Responded, a.MedianDaysToResponse)
+	}
+
+	// Only Initech is open and untouched for two weeks
+	if a.Stale != 1 || a.StaleAfterDays != 14 {
+		t.Errorf("Stale %d after %d days, want 1 after 14", a.Stale, a.StaleAfterDays)
+	}
+
+	wantWeeks := []models.WeekCount{
+		{Week: "2026-04-27", Count: 1}, {Week: "2026-05-04"}, {Week: "2026-05-11"}, {Week: "2026-05-18"},
+		{Week: "2026-05-25"}, {Week: "2026-06-01", Count: 3}, {Week: "2026-06-08", Count: 1}, {Week: "2026-06-15"},
+	}
+	if !reflect.DeepEqual(a.PerWeek, wantWeeks) {
+		t.Errorf("PerWeek = %+v, want %+v", a.PerWeek, wantWeeks)
+	}
+
+	if len(a.ByCompany) != 4 || a.ByCompany[0].Total != 2 || a.ByCompany[0].Offer != 1 || a.ByCompany[0].Rejected != 1 {
+		t.Errorf("ByCompany = %+v, want Acme in either case first with an offer and a rejection", a.ByCompany)
+	}
+}
+
+func TestApplicationAnalyticsEmpty(t *testing.T) {
+	d, u := newTestDB(t)
+	a, err := d.ApplicationAnalytics(u.ID, time.Now(), 14*24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Total != 0 || a.Stale != 0 || a.MedianDaysToResponse != nil || len(a.PerWeek) != 0 || a.Funnel[1].Conversion != 0 {
+		t.Errorf("ApplicationAnalytics() with no applications = %+v", a)
+	}
+}
+
+func TestCVEffectiveness(t *testing.T) {
+	d, u := newTestDB(t)
+	backend, err := d.CreateCV(u.ID, "Backend", models.CVData{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	frontend, err := d.CreateCV(u.ID, "Frontend", models.CVData{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var versions []string
+	for i, message := range []string{"First draft", "Shorter"} {
+		v, err := d.CreateVersion(backend.ID, u.ID, message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := d.conn.Exec(`UPDATE cv_versions SET created_at = ? WHERE id = ?`, time.Date(2026, 5, 1+i, 0, 0, 0, 0, time.UTC), v.ID); err != nil {
+			t.Fatal(err)
+		}
+		versions = append(versions, v.ID)
+	}
+	sent := func(cvID string, versionID *string, moves ...statusMove) {
+		t.Helper()
+		id := seedApplication(t, d, u.ID, "Acme", time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), moves...)
+		if _, err := d.conn.Exec(`UPDATE applications SET cv_id = ?, cv_version_id = ? WHERE id = ?`, cvID, versionID, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	interview := statusMove{"Interviewing", time.Date(2026, 6, 5, 0, 0, 0, 0, time.UTC)}
+	offer := statusMove{"Offer", time.Date(2026, 6, 9, 0, 0, 0, 0, time.UTC)}
+	rejected := statusMove{"Rejected", time.Date(2026, 6, 9, 0, 0, 0, 0, time.UTC)}
+	for i := 0; i < 5; i++ {
+		if i == 0 {
+			sent(backend.ID, &versions[0], interview, offer)
+		} else {
+			sent(backend.ID, &versions[0], interview, rejected)
+		}
+		sent(backend.ID, &versions[1], rejected)
+	}
+	sent(frontend.ID, nil)
+
+	report, err := d.CVEffectiveness(u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := models.NewOutcomeRates(11, 5, 1); report.Overall != want {
+		t.Errorf("Overall = %+v, want %+v", report.Overall, want)
+	}
+	if len(report.CVs) != 2 || report.CVs[0].CVID != backend.ID || report.CVs[1].CVID != frontend.ID {
+		t.Fatalf("CVs = %+v, want Backend then Frontend", report.CVs)
+	}
+	cv := report.CVs[0]
+	if want := models.NewOutcomeRates(10, 5, 1); cv.Outcomes != want || cv.Significance.Significance != models.SignificanceTooFew {
+		t.Errorf("Backend = %+v %s, want %+v with too few to compare", cv.Outcomes, cv.Significance.Significance, want)
+	}
+
+	// The versions are compared with each other, oldest first
+	want := []struct {
+		id           string
+		message      string
+		outcomes     models.OutcomeRates
+		significance models.Significance
+	}{
+		{versions[0], "First draft", models.NewOutcomeRates(5, 5, 1), models.SignificanceBetter},
+		{versions[1], "Shorter", models.NewOutcomeRates(5, 0, 0), models.SignificanceWorse},
+	}
+	if len(cv.Versions) != len(want) {
+		t.Fatalf("Backend versions = %+v, want %d", cv.Versions, len(want))
+	}
+	for i, w := range want {
+		v := cv.Versions[i]
+		if v.VersionID == nil || *v.VersionID != w.id || v.Message != w.message || v.Outcomes != w.outcomes ||
+			v.Significance.Significance != w.significance || v.Significance.PValue == nil || *v.Significance.PValue != 0.008 {
+			t.Errorf("version %d = %+v, want %s %q %+v %s with p 0.008", i, v, w.id, w.message, w.outcomes, w.significance)
+		}
+	}
+	if v := report.CVs[1].Versions; len(v) != 1 || v[0].VersionID != nil {
+		t.Errorf("Frontend versions = %+v, want only the live CV", v)
+	}
+}
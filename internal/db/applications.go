@@ -2,6 +2,8 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/cv-forge/cv-forge/internal/models"
@@ -10,36 +12,103 @@ import (
 
 // ListApplications listing for dashboard.
 func (db *DB) ListApplications(userID string) ([]models.Application, error) {
+	apps, _, err := db.QueryApplications(userID, models.ApplicationFilter{})
+	return apps, err
+}
+
+// applicationSorts are the fields applications can be ordered by. Status
+// follows the order of the pipeline, with statuses of removed stages last.
+var applicationSorts = map[string]sortField{
+	"date":      {expr: "COALESCE(a.date, '')", desc: true},
+	"company":   {expr: "a.company COLLATE NOCASE"},
+	"role":      {expr: "a.role COLLATE NOCASE"},
+	"status":    {expr: "COALESCE(s.position, 1000000)"},
+	"createdAt": {expr: "COALESCE(a.created_at, '')", desc: true},
+	"updatedAt": {expr: "COALESCE(a.updated_at, '')", desc: true},
+}
+
+// QueryApplications returns the applications matching f, newest first
+// unless f says otherwise. With a limit it returns one page, and a cursor
+// for the next one if there is more.
+func (db *DB) QueryApplications(userID string, f models.ApplicationFilter) ([]models.Application, string, error) {
+	page, err := newKeyset(applicationSorts, "date", "a.id", f.ListOptions)
+	if err != nil {
+		return nil, "", err
+	}
+
+	conds := []string{"(a.user_id = ? OR a.user_id IS NULL)"}
+	args := []any{userID, userID}
+	if f.OwnOnly {
+		conds[0] = "a.user_id = ?"
+	}
+	if len(f.Statuses) > 0 {
+		conds = append(conds, "a.status IN (?"+strings.Repeat(", ?", len(f.Statuses)-1)+")")
+		for _, st := range f.Statuses {
+			args = append(args, st)
+		}
+	}
+	if f.CompanyID != "" {
+		conds = append(conds, "a.company_id = ?")
+		args = append(args, f.CompanyID)
+	}
+	if f.Company != "" {
+		conds = append(conds, `a.company LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+escapeLike(f.Company)+"%")
+	}
+	if f.From != nil {
+		conds = append(conds, "substr(a.date, 1, 10) >= ?")
+		args = append(args, f.From.Format("2006-01-02"))
+	}
+	if f.To != nil {
+		conds = append(conds, "substr(a.date, 1, 10) <= ?")
+		args = append(args, f.To.Format("2006-01-02"))
+	}
+	if f.CVID != "" {
+		conds = append(conds, "a.cv_id = ?")
+		args = append(args, f.CVID)
+	}
+	if cond, condArgs := likeTerms(f.Query, "a.role", "a.notes"); cond != "" {
+		conds = append(conds, cond)
+		args = append(args, condArgs...)
+	}
+	if cond, condArgs := page.where(); cond != "" {
+		conds = append(conds, cond)
+		args = append(args, condArgs...)
+	}
+
 	query := `
-		SELECT id, company, role, status, salary, url, date, notes, cv_id, cv_version_id, created_at, updated_at
-		FROM applications
-		WHERE user_id = ? OR user_id IS NULL
-		ORDER BY date DESC
-	`
-	rows, err := db.conn.Query(query, userID)
+		SELECT a.id, a.company_id, a.company, a.role, a.status, a.compensation, a.url, a.source, a.location, a.date, a.notes,
+			a.deadline, a.follow_up_at, a.cv_id, a.cv_version_id, a.created_at, a.updated_at, ` + page.field.expr + `
+		FROM applications a
+		LEFT JOIN pipeline_stages s ON s.user_id = ? AND s.name = a.status
+		WHERE ` + strings.Join(conds, " AND ") + `
+		` + page.orderBy()
+	rows, err := db.conn.Query(query, args...)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer rows.Close()
 
-	var apps []models.Application
+	apps := []models.Application{}
+	var key, lastKey any
 	for rows.Next() {
-		app, err := scanApplication(rows)
+		app, err := scanApplication(keyScanner{rows, &key})
 		if err != nil {
-			return nil, err
+			return nil, "", err
+		}
+		if page.full(len(apps)) {
+			return apps, page.next(lastKey, apps[len(apps)-1].ID), nil
 		}
 		apps = append(apps, app)
+		lastKey = key
 	}
-	if apps == nil {
-		apps = []models.Application{}
-	}
-	return apps, rows.Err()
+	return apps, "", rows.Err()
 }
 
 // GetApplication by ID.
 func (db *DB) GetApplication(id, userID string) (*models.Application, error) {
 	query := `
-		SELECT id, company, role, status, salary, url, date, notes, cv_id, cv_version_id, created_at, updated_at
+		SELECT id, company_id, company, role, status, compensation, url, source, location, date, notes, deadline, follow_up_at, cv_id, cv_version_id, created_at, updated_at
 		FROM applications
 		WHERE id = ? AND (user_id = ? OR user_id IS NULL)
 	`
@@ -56,49 +125,158 @@ func (db *DB) GetApplication(id, userID string) (*models.Application, error) {
 
 // CreateApplication creates a new tracker entry.
 func (db *DB) CreateApplication(userID string, req models.CreateApplicationRequest) (*models.Application, error) {
-	id := uuid.New().String()
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	app, err := insertApplication(tx, userID, req, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// ImportApplications creates applications all at once: if one fails none
+// are created. Those without a company ID are linked to the user's company
+// matching their company name, which is created if needed, as by
+// ResolveCompany.
+func (db *DB) ImportApplications(userID string, reqs []models.CreateApplicationRequest) ([]*models.Application, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	companies, err := listCompanies(tx, userID)
+	if err != nil {
+		return nil, err
+	}
 	now := time.Now().UTC()
+	apps := make([]*models.Application, 0, len(reqs))
+	for _, req := range reqs {
+		if req.CompanyID == nil {
+			c := models.MatchCompany(companies, req.Company)
+			if c == nil {
+				id, err := insertCompany(tx, userID, models.CompanyRequest{Name: req.Company}, now)
+				if err != nil {
+					return nil, err
+				}
+				companies = append(companies, models.Company{ID: id, Name: req.Company, Aliases: []string{}})
+				c = &companies[len(companies)-1]
+			}
+			req.CompanyID, req.Company = &c.ID, c.Name
+		}
+		app, err := insertApplication(tx, userID, req, now)
+		if err != nil {
+			return nil, err
+		}
+		apps = append(apps, app)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return apps, nil
+}
 
-	_, err := db.conn.Exec(
-		`INSERT INTO applications (id, user_id, company, role, status, salary, url, date, notes, cv_id, cv_version_id, created_at, updated_at) 
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
-		id, userID, req.Company, req.Role, req.Status, req.Salary, req.URL, req.Date, req.Notes, req.CVID, req.CVVersionID, now, now,
+// insertApplication stores a new application with its created event.
+func insertApplication(x execer, userID string, req models.CreateApplicationRequest, now time.Time) (*models.Application, error) {
+	id := uuid.New().String()
+	req.Date, req.Deadline, req.FollowUpAt = req.Date.UTC(), utcPtr(req.Deadline), utcPtr(req.FollowUpAt)
+	compensation, err := marshalCompensation(req.Compensation)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = x.Exec(
+		`INSERT INTO applications (id, user_id, company_id, company, role, status, compensation, url, source, location, date, notes, deadline, follow_up_at, cv_id, cv_version_id, created_at, updated_at) 
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, userID, req.CompanyID, req.Company, req.Role, req.Status, compensation, req.URL, req.Source, req.Location, req.Date, req.Notes, req.Deadline, req.FollowUpAt, req.CVID, req.CVVersionID, now, now,
 	)
 	if err != nil {
 		return nil, err
 	}
+	err = insertEvent(x, models.ApplicationEvent{
+		ID:            uuid.New().String(),
+		ApplicationID: id,
+		Type:          models.EventCreated,
+		To:            string(req.Status),
+		OccurredAt:    now,
+		CreatedAt:     now,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return &models.Application{
-		ID:          id,
-		Company:     req.Company,
-		Role:        req.Role,
-		Status:      req.Status,
-		Salary:      req.Salary,
-		URL:         req.URL,
-		Date:        req.Date,
-		Notes:       req.Notes,
-		CVID:        req.CVID,
-		CVVersionID: req.CVVersionID,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:           id,
+		CompanyID:    req.CompanyID,
+		Company:      req.Company,
+		Role:         req.Role,
+		Status:       req.Status,
+		Compensation: req.Compensation,
+		URL:          req.URL,
+		Source:       req.Source,
+		Location:     req.Location,
+		Date:         req.Date,
+		Notes:        req.Notes,
+		Deadline:     req.Deadline,
+		FollowUpAt:   req.FollowUpAt,
+		CVID:         req.CVID,
+		CVVersionID:  req.CVVersionID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
 	}, nil
 }
 
-// UpdateApplication updates an existing entry.
+// UpdateApplication updates an existing entry, recording each changed field
+// on its timeline.
 func (db *DB) UpdateApplication(id, userID string, req models.UpdateApplicationRequest) (*models.Application, error) {
 	now := time.Now().UTC()
-	res, err := db.conn.Exec(
+	req.Date, req.Deadline, req.FollowUpAt = req.Date.UTC(), utcPtr(req.Deadline), utcPtr(req.FollowUpAt)
+	compensation, err := marshalCompensation(req.Compensation)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	old, err := scanApplicationRow(tx.QueryRow(
+		`SELECT id, company_id, company, role, status, compensation, url, source, location, date, notes, deadline, follow_up_at, cv_id, cv_version_id, created_at, updated_at
+		FROM applications
+		WHERE id = ? AND (user_id = ? OR user_id IS NULL)`,
+		id, userID,
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(
 		`UPDATE applications 
-		 SET company=?, role=?, status=?, salary=?, url=?, date=?, notes=?, cv_id=?, cv_version_id=?, updated_at=?, user_id=?
-		 WHERE id=? AND (user_id=? OR user_id IS NULL)`,
-		req.Company, req.Role, req.Status, req.Salary, req.URL, req.Date, req.Notes, req.CVID, req.CVVersionID, now, userID, id, userID,
+		 SET company_id=?, company=?, role=?, status=?, compensation=?, url=?, source=?, location=?, date=?, notes=?, deadline=?, follow_up_at=?, cv_id=?, cv_version_id=?, updated_at=?, user_id=?
+		 WHERE id=?`,
+		req.CompanyID, req.Company, req.Role, req.Status, compensation, req.URL, req.Source, req.Location, req.Date, req.Notes, req.Deadline, req.FollowUpAt, req.CVID, req.CVVersionID, now, userID, id,
 	)
 	if err != nil {
 		return nil, err
 	}
-	n, _ := res.RowsAffected()
-	if n == 0 {
-		return nil, nil
+	for _, e := range applicationChanges(old, req, now) {
+		if err := insertEvent(tx, e); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
 	}
 	return db.GetApplication(id, userID)
 }
@@ -113,18 +291,56 @@ func (db *DB) DeleteApplication(id, userID string) (bool, error) {
 	return n > 0, nil
 }
 
+// backfillCompensation parses the free-text salary of applications predating
+// structured compensation. The text is cleared once migrated, so it is kept
+// only in the compensation note.
+func (db *DB) backfillCompensation() error {
+	rows, err := db.conn.Query(
+		`SELECT id, salary FROM applications WHERE compensation IS NULL AND salary IS NOT NULL AND salary != ''`,
+	)
+	if err != nil {
+		return err
+	}
+	type pending struct{ id, salary string }
+	var apps []pending
+	for rows.Next() {
+		var p pending
+		if err := rows.Scan(&p.id, &p.salary); err != nil {
+			rows.Close()
+			return err
+		}
+		apps = append(apps, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, p := range apps {
+		compensation, err := marshalCompensation(models.ParseSalary(p.salary))
+		if err != nil {
+			return err
+		}
+		if _, err := db.conn.Exec(`UPDATE applications SET compensation = ?, salary = NULL WHERE id = ?`, compensation, p.id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // --- Scanners ---
 
 func scanApplication(s interface{ Scan(...any) error }) (models.Application, error) {
 	var app models.Application
 	var dateStr, createdAt, updatedAt string
+	var deadline, followUpAt, compensation *string
 
 	// Pointers for nullable fields
 	var cvID, cvVersionID *string
 
 	err := s.Scan(
-		&app.ID, &app.Company, &app.Role, &app.Status, &app.Salary, &app.URL,
-		&dateStr, &app.Notes, &cvID, &cvVersionID, &createdAt, &updatedAt,
+		&app.ID, &app.CompanyID, &app.Company, &app.Role, &app.Status, &compensation, &app.URL, &app.Source, &app.Location,
+		&dateStr, &app.Notes, &deadline, &followUpAt, &cvID, &cvVersionID, &createdAt, &updatedAt,
 	)
 	if err != nil {
 		return app, err
@@ -133,7 +349,10 @@ func scanApplication(s interface{ Scan(...any) error }) (models.Application, err
 	app.CVID = cvID
 	app.CVVersionID = cvVersionID
 
+	app.Compensation = unmarshalCompensation(compensation)
 	app.Date, _ = parseTime(dateStr)
+	app.Deadline = parseTimePtr(deadline)
+	app.FollowUpAt = parseTimePtr(followUpAt)
 	app.CreatedAt, _ = parseTime(createdAt)
 	app.UpdatedAt, _ = parseTime(updatedAt)
 
@@ -143,11 +362,12 @@ func scanApplication(s interface{ Scan(...any) error }) (models.Application, err
 func scanApplicationRow(row *sql.Row) (models.Application, error) {
 	var app models.Application
 	var dateStr, createdAt, updatedAt string
+	var deadline, followUpAt, compensation *string
 	var cvID, cvVersionID *string
 
 	err := row.Scan(
-		&app.ID, &app.Company, &app.Role, &app.Status, &app.Salary, &app.URL,
-		&dateStr, &app.Notes, &cvID, &cvVersionID, &createdAt, &updatedAt,
+		&app.ID, &app.CompanyID, &app.Company, &app.Role, &app.Status, &compensation, &app.URL, &app.Source, &app.Location,
+		&dateStr, &app.Notes, &deadline, &followUpAt, &cvID, &cvVersionID, &createdAt, &updatedAt,
 	)
 	if err != nil {
 		return app, err
@@ -156,17 +376,77 @@ func scanApplicationRow(row *sql.Row) (models.Application, error) {
 	app.CVID = cvID
 	app.CVVersionID = cvVersionID
 
+	app.Compensation = unmarshalCompensation(compensation)
 	app.Date, _ = parseTime(dateStr)
+	app.Deadline = parseTimePtr(deadline)
+	app.FollowUpAt = parseTimePtr(followUpAt)
 	app.CreatedAt, _ = parseTime(createdAt)
 	app.UpdatedAt, _ = parseTime(updatedAt)
 
 	return app, nil
 }
 
+// marshalCompensation encodes a nullable compensation for its JSON column.
+func marshalCompensation(c *models.Compensation) (*string, error) {
+	if c == nil {
+		return nil, nil
+	}
+	stored := *c
+	stored.Benefits = nonNil(stored.Benefits)
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return nil, err
+	}
+	s := string(data)
+	return &s, nil
+}
+
+func unmarshalCompensation(s *string) *models.Compensation {
+	if s == nil {
+		return nil
+	}
+	var c models.Compensation
+	if err := json.Unmarshal([]byte(*s), &c); err != nil {
+		return nil
+	}
+	c.Benefits = nonNil(c.Benefits)
+	return &c
+}
+
+// parseTimePtr parses a nullable timestamp column.
+func parseTimePtr(s *string) *time.Time {
+	if s == nil {
+		return nil
+	}
+	t, err := parseTime(*s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
 func parseTime(s string) (time.Time, error) {
 	t, err := time.Parse("2006-01-02 15:04:05+00:00", s)
 	if err != nil {
-		return time.Parse("2006-01-02T15:04:05Z", s)
+		t, err = time.Parse("2006-01-02T15:04:05Z", s)
+	}
+	if err != nil {
+		// Aggregates such as MAX() return the stored text untyped
+		t, err = time.Parse("2006-01-02 15:04:05.999999999 -0700 MST", s)
+	}
+	if err != nil {
+		// Times written outside UTC name their zone by its offset
+		t, err = time.Parse("2006-01-02 15:04:05.999999999 -0700 -0700", s)
+	}
+	return t.UTC(), err
+}
+
+// utcPtr returns a nullable time in UTC. Times are stored in UTC so that
+// they read back and compare as text in order.
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
 	}
-	return t, nil
+	u := t.UTC()
+	return &u
 }
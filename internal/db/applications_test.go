@@ -0,0 +1,93 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+func newTestDB(t *testing.T) (*DB, *models.User) {
+	t.Helper()
+	d, err := New(filepath.Join(t.TempDir(), "data.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { d.Close() })
+	u, err := d.CreateOrUpdateUser("ann@example.com", "Ann Lee", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d, u
+}
+
+func TestImportApplications(t *testing.T) {
+	d, u := newTestDB(t)
+	acme, err := d.CreateCompany(u.ID, models.CompanyRequest{Name: "Acme Inc."})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	date := time.Date(2025, 3, 4, 9, 0, 0, 0, time.FixedZone("", 2*3600))
+	reqs := []models.CreateApplicationRequest{
+		{Company: "ACME", Role: "Engineer", Status: "Applied", Date: date},
+		{Company: "Globex", Role: "Analyst", Status: "Applied", Date: date},
+		{Company: "globex", Role: "Designer", Status: "Applied", Date: date},
+	}
+	apps, err := d.ImportApplications(u.ID, reqs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != len(reqs) {
+		t.Fatalf("ImportApplications() created %d applications, want %d", len(apps), len(reqs))
+	}
+	if apps[0].CompanyID == nil || *apps[0].CompanyID != acme.ID || apps[0].Company != "Acme Inc." {
+		t.Errorf("first application linked to %v %q, want the existing company %s", apps[0].CompanyID, apps[0].Company, acme.ID)
+	}
+	// Rows naming the same new company share it
+	if apps[1].CompanyID == nil || apps[2].CompanyID == nil || *apps[1].CompanyID != *apps[2].CompanyID {
+		t.Errorf("applications at Globex linked to %v and %v, want one company", apps[1].CompanyID, apps[2].CompanyID)
+	}
+	if !apps[0].Date.Equal(date) || apps[0].Date.Location() != time.UTC {
+		t.Errorf("Date = %v, want %v in UTC", apps[0].Date, date)
+	}
+	companies, err := d.ListCompanies(u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(companies) != 2 {
+		t.Errorf("%d companies after import, want 2", len(companies))
+	}
+	events, err := d.ListEvents(apps[1].ID, u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Type != models.EventCreated {
+		t.Errorf("events = %+v, want one created event", events)
+	}
+}
+
+func TestImportApplicationsAllOrNothing(t *testing.T) {
+	d, u := newTestDB(t)
+	missingCV := "no-such-cv"
+	reqs := []models.CreateApplicationRequest{
+		{Company: "Initech", Role: "Engineer", Status: "Applied", Date: time.Now()},
+		{Company: "Umbrella", Role: "Analyst", Status: "Applied", Date: time.Now(), CVID: &missingCV},
+	}
+	if _, err := d.ImportApplications(u.ID, reqs); err == nil {
+		t.Fatal("ImportApplications() with a missing CV succeeded")
+	}
+
+	apps, err := d.ListApplications(u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	companies, err := d.ListCompanies(u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 0 || len(companies) != 0 {
+		t.Errorf("failed import left %d applications and %d companies, want none", len(apps), len(companies))
+	}
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/google/uuid"
+)
+
+const attachmentColumns = `t.id, t.application_id, t.kind, t.blob_id, b.filename, b.content_type, b.size, t.created_at`
+
+// ListAttachments returns the attachments of an application.
+func (db *DB) ListAttachments(appID, userID string) ([]models.Attachment, error) {
+	rows, err := db.conn.Query(
+		`SELECT `+attachmentColumns+`
+		FROM application_attachments t
+		JOIN blobs b ON b.id = t.blob_id
+		JOIN applications a ON a.id = t.application_id
+		WHERE t.application_id = ? AND (a.user_id = ? OR a.user_id IS NULL)
+		ORDER BY t.created_at`,
+		appID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var atts []models.Attachment
+	for rows.Next() {
+		att, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		atts = append(atts, att)
+	}
+	if atts == nil {
+		atts = []models.Attachment{}
+	}
+	return atts, rows.Err()
+}
+
+// GetAttachment returns a single attachment of an application.
+func (db *DB) GetAttachment(appID, id, userID string) (*models.Attachment, error) {
+	row := db.conn.QueryRow(
+		`SELECT `+attachmentColumns+`
+		FROM application_attachments t
+		JOIN blobs b ON b.id = t.blob_id
+		JOIN applications a ON a.id = t.application_id
+		WHERE t.id = ? AND t.application_id = ? AND (a.user_id = ? OR a.user_id IS NULL)`,
+		id, appID, userID,
+	)
+	att, err := scanAttachment(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &att, nil
+}
+
+// CreateAttachment links a stored blob to an application.
+func (db *DB) CreateAttachment(appID string, kind models.AttachmentKind, b models.Blob) (*models.Attachment, error) {
+	id := uuid.New().String()
+	now := time.Now().UTC()
+	_, err := db.conn.Exec(
+		`INSERT INTO application_attachments (id, application_id, blob_id, kind, created_at) VALUES (?, ?, ?, ?, ?)`,
+		id, appID, b.ID, kind, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Attachment{
+		ID:            id,
+		ApplicationID: appID,
+		Kind:          kind,
+		BlobID:        b.ID,
+		Filename:      b.Filename,
+		ContentType:   b.ContentType,
+		Size:          b.Size,
+		CreatedAt:     now,
+	}, nil
+}
+
+func scanAttachment(s scanner) (models.Attachment, error) {
+	var att models.Attachment
+	var createdAt string
+	err := s.Scan(&att.ID, &att.ApplicationID, &att.Kind, &att.BlobID, &att.Filename, &att.ContentType, &att.Size, &createdAt)
+	if err != nil {
+		return att, err
+	}
+	att.CreatedAt, _ = parseTime(createdAt)
+	return att, nil
+}
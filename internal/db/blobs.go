@@ -0,0 +1,52 @@
+package db
+
+import (
+	"database/sql"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// CreateBlob records the metadata of a blob written to the blob store.
+func (db *DB) CreateBlob(userID string, b models.Blob) error {
+	_, err := db.conn.Exec(
+		`INSERT INTO blobs (id, user_id, filename, content_type, size, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		b.ID, userID, b.Filename, b.ContentType, b.Size, b.CreatedAt,
+	)
+	return err
+}
+
+// GetBlob returns a blob's metadata if it belongs to the user.
+func (db *DB) GetBlob(id, userID string) (*models.Blob, error) {
+	row := db.conn.QueryRow(
+		`SELECT id, filename, content_type, size, created_at FROM blobs WHERE id = ? AND user_id = ?`,
+		id, userID,
+	)
+	b, err := scanBlob(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// DeleteBlob removes a blob's metadata. The caller deletes the content.
+func (db *DB) DeleteBlob(id, userID string) (bool, error) {
+	res, err := db.conn.Exec(`DELETE FROM blobs WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func scanBlob(s scanner) (models.Blob, error) {
+	var b models.Blob
+	var createdAt string
+	if err := s.Scan(&b.ID, &b.Filename, &b.ContentType, &b.Size, &createdAt); err != nil {
+		return b, err
+	}
+	b.CreatedAt, _ = parseTime(createdAt)
+	return b, nil
+}
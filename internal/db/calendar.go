@@ -0,0 +1,54 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+)
+
+// SetCalendarToken stores the hash of the user's calendar feed token,
+// replacing any previous one.
+func (db *DB) SetCalendarToken(userID, tokenHash string) (time.Time, error) {
+	now := time.Now().UTC()
+	_, err := db.conn.Exec(
+		`INSERT INTO calendar_tokens (user_id, token_hash, created_at) VALUES (?, ?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET token_hash = excluded.token_hash, created_at = excluded.created_at`,
+		userID, tokenHash, now,
+	)
+	return now, err
+}
+
+// GetCalendarTokenCreated returns when the user's calendar feed token was
+// issued, or nil if the feed is disabled.
+func (db *DB) GetCalendarTokenCreated(userID string) (*time.Time, error) {
+	var createdAt string
+	err := db.conn.QueryRow(`SELECT created_at FROM calendar_tokens WHERE user_id = ?`, userID).Scan(&createdAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	t, _ := parseTime(createdAt)
+	return &t, nil
+}
+
+// DeleteCalendarToken disables the user's calendar feed.
+func (db *DB) DeleteCalendarToken(userID string) (bool, error) {
+	res, err := db.conn.Exec(`DELETE FROM calendar_tokens WHERE user_id = ?`, userID)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// UserIDByCalendarToken returns the owner of a calendar feed token hash,
+// or "" if no feed uses it.
+func (db *DB) UserIDByCalendarToken(tokenHash string) (string, error) {
+	var userID string
+	err := db.conn.QueryRow(`SELECT user_id FROM calendar_tokens WHERE token_hash = ?`, tokenHash).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return userID, err
+}
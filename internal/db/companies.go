@@ -0,0 +1,322 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrCompanyInUse is returned when deleting a company that applications
+// still reference.
+var ErrCompanyInUse = errors.New("company in use")
+
+const companyColumns = `c.id, c.name, c.aliases, c.website, c.industry, c.size, c.location, c.notes,
+	(SELECT COUNT(*) FROM applications a WHERE a.company_id = c.id), c.created_at, c.updated_at`
+
+// ListCompanies returns the user's companies by name.
+func (db *DB) ListCompanies(userID string) ([]models.Company, error) {
+	return listCompanies(db.conn, userID)
+}
+
+func listCompanies(q queryer, userID string) ([]models.Company, error) {
+	rows, err := q.Query(
+		`SELECT `+companyColumns+` FROM companies c WHERE c.user_id = ? ORDER BY c.name COLLATE NOCASE`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	companies := []models.Company{}
+	for rows.Next() {
+		c, err := scanCompany(rows)
+		if err != nil {
+			return nil, err
+		}
+		companies = append(companies, c)
+	}
+	return companies, rows.Err()
+}
+
+// GetCompany returns a company with its applications.
+func (db *DB) GetCompany(id, userID string) (*models.Company, error) {
+	c, err := scanCompany(db.conn.QueryRow(
+		`SELECT `+companyColumns+` FROM companies c WHERE c.id = ? AND c.user_id = ?`,
+		id, userID,
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.conn.Query(
+		`SELECT id, company_id, company, role, status, compensation, url, source, location, date, notes, deadline, follow_up_at, cv_id, cv_version_id, created_at, updated_at
+		FROM applications WHERE company_id = ? ORDER BY date DESC`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		app, err := scanApplication(rows)
+		if err != nil {
+			return nil, err
+		}
+		c.Applications = append(c.Applications, app)
+	}
+	return &c, rows.Err()
+}
+
+// CreateCompany stores a new company.
+func (db *DB) CreateCompany(userID string, req models.CompanyRequest) (*models.Company, error) {
+	id, err := insertCompany(db.conn, userID, req, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	return db.GetCompany(id, userID)
+}
+
+func insertCompany(x execer, userID string, req models.CompanyRequest, now time.Time) (string, error) {
+	aliases, err := json.Marshal(nonNil(req.Aliases))
+	if err != nil {
+		return "", err
+	}
+	id := uuid.New().String()
+	_, err = x.Exec(
+		`INSERT INTO companies (id, user_id, name, aliases, website, industry, size, location, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, userID, req.Name, string(aliases), req.Website, req.Industry, req.Size, req.Location, req.Notes, now, now,
+	)
+	return id, err
+}
+
+// UpdateCompany replaces a company's details. A new name is copied to its
+// applications.
+func (db *DB) UpdateCompany(id, userID string, req models.CompanyRequest) (*models.Company, error) {
+	aliases, err := json.Marshal(nonNil(req.Aliases))
+	if err != nil {
+		return nil, err
+	}
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(
+		`UPDATE companies SET name = ?, aliases = ?, website = ?, industry = ?, size = ?, location = ?, notes = ?, updated_at = ?
+		WHERE id = ? AND user_id = ?`,
+		req.Name, string(aliases), req.Website, req.Industry, req.Size, req.Location, req.Notes, time.Now().UTC(), id, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, nil
+	}
+	if _, err := tx.Exec(`UPDATE applications SET company = ? WHERE company_id = ?`, req.Name, id); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return db.GetCompany(id, userID)
+}
+
+// DeleteCompany removes a company no application references.
+func (db *DB) DeleteCompany(id, userID string) (bool, error) {
+	var n int
+	err := db.conn.QueryRow(
+		`SELECT COUNT(*) FROM applications a JOIN companies c ON c.id = a.company_id
+		WHERE c.id = ? AND c.user_id = ?`,
+		id, userID,
+	).Scan(&n)
+	if err != nil {
+		return false, err
+	}
+	if n > 0 {
+		return false, fmt.Errorf("%w: %d applications", ErrCompanyInUse, n)
+	}
+	res, err := db.conn.Exec(`DELETE FROM companies WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return false, err
+	}
+	affected, _ := res.RowsAffected()
+	return affected > 0, nil
+}
+
+// ResolveCompany returns the user's company known by name, creating it if
+// there is none.
+func (db *DB) ResolveCompany(userID, name string) (*models.Company, error) {
+	companies, err := db.ListCompanies(userID)
+	if err != nil {
+		return nil, err
+	}
+	if c := models.MatchCompany(companies, name); c != nil {
+		return c, nil
+	}
+	return db.CreateCompany(userID, models.CompanyRequest{Name: name})
+}
+
+// MergeCompanies folds the source companies into the target: their
+// applications move over, their names become aliases, and details the
+// target lacks are taken from them. It returns nil if any company does not
+// exist.
+func (db *DB) MergeCompanies(userID, targetID string, sourceIDs []string) (*models.Company, error) {
+	target, err := db.GetCompany(targetID, userID)
+	if err != nil || target == nil {
+		return nil, err
+	}
+	merged := models.CompanyRequest{
+		Name:     target.Name,
+		Aliases:  target.Aliases,
+		Website:  target.Website,
+		Industry: target.Industry,
+		Size:     target.Size,
+		Location: target.Location,
+		Notes:    target.Notes,
+	}
+	seen := map[string]bool{models.CompanyKey(target.Name): true}
+	for _, a := range target.Aliases {
+		seen[models.CompanyKey(a)] = true
+	}
+	addAlias := func(a string) {
+		if k := models.CompanyKey(a); k != "" && !seen[k] {
+			seen[k] = true
+			merged.Aliases = append(merged.Aliases, a)
+		}
+	}
+	fill := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+
+	var sources []*models.Company
+	for _, id := range sourceIDs {
+		if id == targetID {
+			continue
+		}
+		src, err := db.GetCompany(id, userID)
+		if err != nil || src == nil {
+			return nil, err
+		}
+		sources = append(sources, src)
+		addAlias(src.Name)
+		for _, a := range src.Aliases {
+			addAlias(a)
+		}
+		fill(&merged.Website, src.Website)
+		fill(&merged.Industry, src.Industry)
+		fill(&merged.Size, src.Size)
+		fill(&merged.Location, src.Location)
+		if src.Notes != "" && src.Notes != merged.Notes {
+			if merged.Notes != "" {
+				merged.Notes += "\n\n"
+			}
+			merged.Notes += src.Notes
+		}
+	}
+
+	aliases, err := json.Marshal(nonNil(merged.Aliases))
+	if err != nil {
+		return nil, err
+	}
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	for _, src := range sources {
+		if _, err := tx.Exec(
+			`UPDATE applications SET company_id = ?, company = ? WHERE company_id = ?`,
+			targetID, target.Name, src.ID,
+		); err != nil {
+			return nil, err
+		}
+		if _, err := tx.Exec(`DELETE FROM companies WHERE id = ? AND user_id = ?`, src.ID, userID); err != nil {
+			return nil, err
+		}
+	}
+	_, err = tx.Exec(
+		`UPDATE companies SET aliases = ?, website = ?, industry = ?, size = ?, location = ?, notes = ?, updated_at = ?
+		WHERE id = ? AND user_id = ?`,
+		string(aliases), merged.Website, merged.Industry, merged.Size, merged.Location, merged.Notes, time.Now().UTC(), targetID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return db.GetCompany(targetID, userID)
+}
+
+// backfillCompanies links applications created before companies existed to
+// a company, grouping spellings that share a key.
+func (db *DB) backfillCompanies() error {
+	rows, err := db.conn.Query(
+		`SELECT id, user_id, company FROM applications WHERE company_id IS NULL AND user_id IS NOT NULL ORDER BY created_at`,
+	)
+	if err != nil {
+		return err
+	}
+	type pending struct{ id, userID, company string }
+	var apps []pending
+	for rows.Next() {
+		var p pending
+		if err := rows.Scan(&p.id, &p.userID, &p.company); err != nil {
+			rows.Close()
+			return err
+		}
+		apps = append(apps, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, p := range apps {
+		c, err := db.ResolveCompany(p.userID, p.company)
+		if err != nil {
+			return err
+		}
+		if _, err := db.conn.Exec(`UPDATE applications SET company_id = ? WHERE id = ?`, c.ID, p.id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanCompany(s scanner) (models.Company, error) {
+	var c models.Company
+	var aliases, createdAt, updatedAt string
+	err := s.Scan(&c.ID, &c.Name, &aliases, &c.Website, &c.Industry, &c.Size, &c.Location, &c.Notes,
+		&c.ApplicationCount, &createdAt, &updatedAt)
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal([]byte(aliases), &c.Aliases); err != nil || c.Aliases == nil {
+		c.Aliases = []string{}
+	}
+	c.CreatedAt, _ = parseTime(createdAt)
+	c.UpdatedAt, _ = parseTime(updatedAt)
+	return c, nil
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
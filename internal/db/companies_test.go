@@ -0,0 +1,43 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+func TestDeleteCompany(t *testing.T) {
+	d, u := newTestDB(t)
+	other, err := d.CreateOrUpdateUser("bob@example.com", "Bob Ray", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	acme, err := d.CreateCompany(u.ID, models.CompanyRequest{Name: "Acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = d.CreateApplication(u.ID, models.CreateApplicationRequest{
+		CompanyID: &acme.ID, Company: acme.Name, Role: "Engineer", Status: "Applied", Date: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another user learns nothing about the company, not even that it is in use
+	if deleted, err := d.DeleteCompany(acme.ID, other.ID); deleted || err != nil {
+		t.Errorf("DeleteCompany() by another user = %v, %v, want false, nil", deleted, err)
+	}
+	if _, err := d.DeleteCompany(acme.ID, u.ID); !errors.Is(err, ErrCompanyInUse) {
+		t.Errorf("DeleteCompany() of a company in use error = %v, want %v", err, ErrCompanyInUse)
+	}
+
+	initech, err := d.CreateCompany(u.ID, models.CompanyRequest{Name: "Initech"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted, err := d.DeleteCompany(initech.ID, u.ID); !deleted || err != nil {
+		t.Errorf("DeleteCompany() of an unused company = %v, %v, want true, nil", deleted, err)
+	}
+}
@@ -0,0 +1,297 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/google/uuid"
+)
+
+const contactColumns = `c.id, c.name, c.company, c.role, c.email, c.phone, c.linkedin, c.notes,
+	(SELECT MAX(i.occurred_at) FROM contact_interactions i WHERE i.contact_id = c.id), c.created_at, c.updated_at`
+
+// SearchContacts returns the user's contacts matching all the conditions
+// of s, ordered by name: the query in their name, company, role, email or
+// notes, the company in theirs or that of a linked application, a link to
+// the application, and an interaction in the date range.
+func (db *DB) SearchContacts(userID string, s models.ContactSearch) ([]models.Contact, error) {
+	rows, err := db.conn.Query(
+		`SELECT `+contactColumns+`
+		FROM contacts c
+		WHERE c.user_id = ?
+		AND (? = '' OR instr(lower(c.name || ' ' || c.company || ' ' || c.role || ' ' || c.email || ' ' || c.notes), lower(?)) > 0)
+		AND (? = '' OR instr(lower(c.company), lower(?)) > 0 OR EXISTS (
+			SELECT 1 FROM application_contacts ac JOIN applications a ON a.id = ac.application_id
+			WHERE ac.contact_id = c.id AND instr(lower(a.company), lower(?)) > 0))
+		AND (? = '' OR EXISTS (
+			SELECT 1 FROM application_contacts ac WHERE ac.contact_id = c.id AND ac.application_id = ?))
+		ORDER BY c.name COLLATE NOCASE`,
+		userID, s.Query, s.Query, s.Company, s.Company, s.Company, s.ApplicationID, s.ApplicationID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contacts := []models.Contact{}
+	for rows.Next() {
+		c, err := scanContact(rows)
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if s.From == nil && s.To == nil {
+		return contacts, nil
+	}
+
+	// Timestamps are compared after parsing rather than as stored strings
+	inRange, err := db.contactsInteractedBetween(userID, s.From, s.To)
+	if err != nil {
+		return nil, err
+	}
+	filtered := []models.Contact{}
+	for _, c := range contacts {
+		if inRange[c.ID] {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered, nil
+}
+
+// GetContact returns a contact with its linked applications and
+// interaction log.
+func (db *DB) GetContact(id, userID string) (*models.Contact, error) {
+	c, err := scanContact(db.conn.QueryRow(
+		`SELECT `+contactColumns+` FROM contacts c WHERE c.id = ? AND c.user_id = ?`,
+		id, userID,
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.Applications, err = db.queryContactApplications(
+		`WHERE ac.contact_id = ? ORDER BY a.date DESC`, id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.Interactions, err = db.listInteractions(id)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// CreateContact stores a new contact.
+func (db *DB) CreateContact(userID string, req models.ContactRequest) (*models.Contact, error) {
+	id := uuid.New().String()
+	now := time.Now().UTC()
+	_, err := db.conn.Exec(
+		`INSERT INTO contacts (id, user_id, name, company, role, email, phone, linkedin, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, userID, req.Name, req.Company, req.Role, req.Email, req.Phone, req.LinkedIn, req.Notes, now, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return db.GetContact(id, userID)
+}
+
+// UpdateContact replaces a contact's details.
+func (db *DB) UpdateContact(id, userID string, req models.ContactRequest) (*models.Contact, error) {
+	res, err := db.conn.Exec(
+		`UPDATE contacts SET name = ?, company = ?, role = ?, email = ?, phone = ?, linkedin = ?, notes = ?, updated_at = ?
+		WHERE id = ? AND user_id = ?`,
+		req.Name, req.Company, req.Role, req.Email, req.Phone, req.LinkedIn, req.Notes, time.Now().UTC(), id, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return nil, nil
+	}
+	return db.GetContact(id, userID)
+}
+
+// DeleteContact removes a contact, its links and its interaction log.
+func (db *DB) DeleteContact(id, userID string) (bool, error) {
+	res, err := db.conn.Exec(`DELETE FROM contacts WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// ListApplicationContacts returns the user's contacts linked to an
+// application. Applications without an owner are shared, so other users'
+// contacts linked to them are left out.
+func (db *DB) ListApplicationContacts(appID, userID string) ([]models.ContactApplication, error) {
+	return db.queryContactApplications(`WHERE ac.application_id = ? AND c.user_id = ? ORDER BY c.name COLLATE NOCASE`, appID, userID)
+}
+
+// LinkContact links a contact to an application, or changes the
+// relationship of an existing link. Ownership of both is checked by the
+// caller.
+func (db *DB) LinkContact(appID, contactID string, rel models.ContactRelationship) error {
+	_, err := db.conn.Exec(
+		`INSERT INTO application_contacts (application_id, contact_id, relationship, created_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT(application_id, contact_id) DO UPDATE SET relationship = excluded.relationship`,
+		appID, contactID, rel, time.Now().UTC(),
+	)
+	return err
+}
+
+// UnlinkContact removes the link between a contact and an application.
+func (db *DB) UnlinkContact(appID, contactID, userID string) (bool, error) {
+	res, err := db.conn.Exec(
+		`DELETE FROM application_contacts WHERE application_id = ? AND contact_id = ?
+		AND contact_id IN (SELECT id FROM contacts WHERE user_id = ?)`,
+		appID, contactID, userID,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// CreateInteraction logs an interaction with a contact. Ownership of the
+// contact and application is checked by the caller.
+func (db *DB) CreateInteraction(contactID string, req models.InteractionRequest) (*models.ContactInteraction, error) {
+	now := time.Now().UTC()
+	in := models.ContactInteraction{
+		ID:            uuid.New().String(),
+		ContactID:     contactID,
+		ApplicationID: req.ApplicationID,
+		Type:          req.Type,
+		Summary:       req.Summary,
+		OccurredAt:    now,
+		CreatedAt:     now,
+	}
+	if req.OccurredAt != nil {
+		in.OccurredAt = req.OccurredAt.UTC()
+	}
+	_, err := db.conn.Exec(
+		`INSERT INTO contact_interactions (id, contact_id, application_id, type, summary, occurred_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		in.ID, in.ContactID, in.ApplicationID, in.Type, in.Summary, in.OccurredAt, in.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &in, nil
+}
+
+// DeleteInteraction removes an entry of a contact's interaction log.
+func (db *DB) DeleteInteraction(contactID, id, userID string) (bool, error) {
+	res, err := db.conn.Exec(
+		`DELETE FROM contact_interactions WHERE id = ? AND contact_id = ?
+		AND contact_id IN (SELECT id FROM contacts WHERE user_id = ?)`,
+		id, contactID, userID,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func (db *DB) listInteractions(contactID string) ([]models.ContactInteraction, error) {
+	rows, err := db.conn.Query(
+		`SELECT id, contact_id, application_id, type, summary, occurred_at, created_at
+		FROM contact_interactions WHERE contact_id = ? ORDER BY occurred_at DESC`,
+		contactID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var interactions []models.ContactInteraction
+	for rows.Next() {
+		var in models.ContactInteraction
+		var occurredAt, createdAt string
+		err := rows.Scan(&in.ID, &in.ContactID, &in.ApplicationID, &in.Type, &in.Summary, &occurredAt, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+		in.OccurredAt, _ = parseTime(occurredAt)
+		in.CreatedAt, _ = parseTime(createdAt)
+		interactions = append(interactions, in)
+	}
+	return interactions, rows.Err()
+}
+
+func (db *DB) contactsInteractedBetween(userID string, from, to *time.Time) (map[string]bool, error) {
+	rows, err := db.conn.Query(
+		`SELECT i.contact_id, i.occurred_at FROM contact_interactions i
+		JOIN contacts c ON c.id = i.contact_id WHERE c.user_id = ?`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := map[string]bool{}
+	for rows.Next() {
+		var contactID, occurredAt string
+		if err := rows.Scan(&contactID, &occurredAt); err != nil {
+			return nil, err
+		}
+		t, _ := parseTime(occurredAt)
+		if (from == nil || !t.Before(*from)) && (to == nil || t.Before(*to)) {
+			ids[contactID] = true
+		}
+	}
+	return ids, rows.Err()
+}
+
+func (db *DB) queryContactApplications(where string, args ...any) ([]models.ContactApplication, error) {
+	rows, err := db.conn.Query(
+		`SELECT ac.contact_id, c.name, ac.application_id, a.company, a.role, a.status, ac.relationship
+		FROM application_contacts ac
+		JOIN contacts c ON c.id = ac.contact_id
+		JOIN applications a ON a.id = ac.application_id `+where,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []models.ContactApplication{}
+	for rows.Next() {
+		var l models.ContactApplication
+		if err := rows.Scan(&l.ContactID, &l.ContactName, &l.ApplicationID, &l.Company, &l.Role, &l.Status, &l.Relationship); err != nil {
+			return nil, err
+		}
+		links = append(links, l)
+	}
+	return links, rows.Err()
+}
+
+func scanContact(s scanner) (models.Contact, error) {
+	var c models.Contact
+	var lastInteraction *string
+	var createdAt, updatedAt string
+	err := s.Scan(&c.ID, &c.Name, &c.Company, &c.Role, &c.Email, &c.Phone, &c.LinkedIn, &c.Notes,
+		&lastInteraction, &createdAt, &updatedAt)
+	if err != nil {
+		return c, err
+	}
+	c.LastInteractionAt = parseTimePtr(lastInteraction)
+	c.CreatedAt, _ = parseTime(createdAt)
+	c.UpdatedAt, _ = parseTime(updatedAt)
+	return c, nil
+}
@@ -0,0 +1,310 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/google/uuid"
+)
+
+// --- Cover letter templates ---
+
+// ListCoverLetterTemplates returns the user's cover letter templates.
+func (db *DB) ListCoverLetterTemplates(userID string) ([]models.CoverLetterTemplate, error) {
+	rows, err := db.conn.Query(
+		`SELECT id, name, body, created_at, updated_at FROM cover_letter_templates WHERE user_id = ? ORDER BY name`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := []models.CoverLetterTemplate{}
+	for rows.Next() {
+		t, err := scanCoverLetterTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+// GetCoverLetterTemplate returns a cover letter template by ID.
+func (db *DB) GetCoverLetterTemplate(id, userID string) (*models.CoverLetterTemplate, error) {
+	row := db.conn.QueryRow(
+		`SELECT id, name, body, created_at, updated_at FROM cover_letter_templates WHERE id = ? AND user_id = ?`,
+		id, userID,
+	)
+	t, err := scanCoverLetterTemplate(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// CreateCoverLetterTemplate stores a new cover letter template.
+func (db *DB) CreateCoverLetterTemplate(userID string, req models.CoverLetterTemplateRequest) (*models.CoverLetterTemplate, error) {
+	id := uuid.New().String()
+	now := time.Now().UTC()
+	_, err := db.conn.Exec(
+		`INSERT INTO cover_letter_templates (id, user_id, name, body, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		id, userID, req.Name, req.Body, now, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CoverLetterTemplate{ID: id, Name: req.Name, Body: req.Body, CreatedAt: now, UpdatedAt: now}, nil
+}
+
+// UpdateCoverLetterTemplate updates a cover letter template. Letters
+// already created from it are not changed.
+func (db *DB) UpdateCoverLetterTemplate(id, userID string, req models.CoverLetterTemplateRequest) (*models.CoverLetterTemplate, error) {
+	res, err := db.conn.Exec(
+		`UPDATE cover_letter_templates SET name = ?, body = ?, updated_at = ? WHERE id = ? AND user_id = ?`,
+		req.Name, req.Body, time.Now().UTC(), id, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return nil, nil
+	}
+	return db.GetCoverLetterTemplate(id, userID)
+}
+
+// DeleteCoverLetterTemplate deletes a cover letter template. Letters
+// created from it are kept.
+func (db *DB) DeleteCoverLetterTemplate(id, userID string) (bool, error) {
+	res, err := db.conn.Exec(`DELETE FROM cover_letter_templates WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func scanCoverLetterTemplate(s scanner) (models.CoverLetterTemplate, error) {
+	var t models.CoverLetterTemplate
+	var createdAt, updatedAt string
+	if err := s.Scan(&t.ID, &t.Name, &t.Body, &createdAt, &updatedAt); err != nil {
+		return t, err
+	}
+	t.CreatedAt, _ = parseTime(createdAt)
+	t.UpdatedAt, _ = parseTime(updatedAt)
+	return t, nil
+}
+
+// --- Cover letters ---
+
+const coverLetterColumns = `l.id, l.application_id, l.template_id, l.cv_id, l.cv_version_id, l.title, l.body, l.created_at, l.updated_at`
+
+// ListCoverLetters returns the cover letters of an application, newest
+// first.
+func (db *DB) ListCoverLetters(appID, userID string) ([]models.CoverLetter, error) {
+	rows, err := db.conn.Query(
+		`SELECT `+coverLetterColumns+`
+		FROM cover_letters l
+		JOIN applications a ON a.id = l.application_id
+		WHERE l.application_id = ? AND (a.user_id = ? OR a.user_id IS NULL)
+		ORDER BY l.created_at DESC`,
+		appID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	letters := []models.CoverLetter{}
+	for rows.Next() {
+		l, err := scanCoverLetter(rows)
+		if err != nil {
+			return nil, err
+		}
+		letters = append(letters, l)
+	}
+	return letters, rows.Err()
+}
+
+// GetCoverLetter returns a single cover letter of an application.
+func (db *DB) GetCoverLetter(appID, id, userID string) (*models.CoverLetter, error) {
+	row := db.conn.QueryRow(
+		`SELECT `+coverLetterColumns+`
+		FROM cover_letters l
+		JOIN applications a ON a.id = l.application_id
+		WHERE l.id = ? AND l.application_id = ? AND (a.user_id = ? OR a.user_id IS NULL)`,
+		id, appID, userID,
+	)
+	l, err := scanCoverLetter(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// CreateCoverLetter stores a cover letter for an application. The caller
+// fills in the body. It returns nil if the application does not exist.
+func (db *DB) CreateCoverLetter(appID, userID string, req models.CreateCoverLetterRequest) (*models.CoverLetter, error) {
+	app, err := db.GetApplication(appID, userID)
+	if err != nil || app == nil {
+		return nil, err
+	}
+	id := uuid.New().String()
+	now := time.Now().UTC()
+	_, err = db.conn.Exec(
+		`INSERT INTO cover_letters (id, application_id, template_id, cv_id, cv_version_id, title, body, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, appID, req.TemplateID, req.CVID, req.CVVersionID, req.Title, req.Body, now, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return db.GetCoverLetter(appID, id, userID)
+}
+
+// UpdateCoverLetter replaces a cover letter's title and body.
+func (db *DB) UpdateCoverLetter(appID, id, userID string, req models.UpdateCoverLetterRequest) (*models.CoverLetter, error) {
+	res, err := db.conn.Exec(
+		`UPDATE cover_letters SET title = ?, body = ?, updated_at = ?
+		WHERE id = ? AND application_id = ?
+		AND application_id IN (SELECT id FROM applications WHERE user_id = ? OR user_id IS NULL)`,
+		req.Title, req.Body, time.Now().UTC(), id, appID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return nil, nil
+	}
+	return db.GetCoverLetter(appID, id, userID)
+}
+
+// DeleteCoverLetter deletes a cover letter and its versions.
+func (db *DB) DeleteCoverLetter(appID, id, userID string) (bool, error) {
+	res, err := db.conn.Exec(
+		`DELETE FROM cover_letters WHERE id = ? AND application_id = ?
+		AND application_id IN (SELECT id FROM applications WHERE user_id = ? OR user_id IS NULL)`,
+		id, appID, userID,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func scanCoverLetter(s scanner) (models.CoverLetter, error) {
+	var l models.CoverLetter
+	var createdAt, updatedAt string
+	err := s.Scan(&l.ID, &l.ApplicationID, &l.TemplateID, &l.CVID, &l.CVVersionID, &l.Title, &l.Body, &createdAt, &updatedAt)
+	if err != nil {
+		return l, err
+	}
+	l.CreatedAt, _ = parseTime(createdAt)
+	l.UpdatedAt, _ = parseTime(updatedAt)
+	return l, nil
+}
+
+// --- Cover letter versions ---
+
+// ListCoverLetterVersions returns the versions of a cover letter, newest
+// first. The caller checks that the letter belongs to the user.
+func (db *DB) ListCoverLetterVersions(letterID string) ([]models.CoverLetterVersion, error) {
+	rows, err := db.conn.Query(
+		`SELECT id, cover_letter_id, title, body, message, created_at FROM cover_letter_versions
+		WHERE cover_letter_id = ? ORDER BY created_at DESC`,
+		letterID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := []models.CoverLetterVersion{}
+	for rows.Next() {
+		v, err := scanCoverLetterVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+// GetCoverLetterVersion returns a version of a cover letter. The caller
+// checks that the letter belongs to the user.
+func (db *DB) GetCoverLetterVersion(letterID, versionID string) (*models.CoverLetterVersion, error) {
+	row := db.conn.QueryRow(
+		`SELECT id, cover_letter_id, title, body, message, created_at FROM cover_letter_versions
+		WHERE id = ? AND cover_letter_id = ?`,
+		versionID, letterID,
+	)
+	v, err := scanCoverLetterVersion(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// CreateCoverLetterVersion snapshots the current text of a cover letter.
+// It returns nil if the letter does not exist.
+func (db *DB) CreateCoverLetterVersion(appID, letterID, userID, message string) (*models.CoverLetterVersion, error) {
+	l, err := db.GetCoverLetter(appID, letterID, userID)
+	if err != nil || l == nil {
+		return nil, err
+	}
+	id := uuid.New().String()
+	now := time.Now().UTC()
+	_, err = db.conn.Exec(
+		`INSERT INTO cover_letter_versions (id, cover_letter_id, title, body, message, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		id, letterID, l.Title, l.Body, message, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CoverLetterVersion{
+		ID:            id,
+		CoverLetterID: letterID,
+		Title:         l.Title,
+		Body:          l.Body,
+		Message:       message,
+		CreatedAt:     now,
+	}, nil
+}
+
+// RestoreCoverLetterVersion replaces a cover letter's text with that of a
+// version. It returns nil if the letter or version does not exist.
+func (db *DB) RestoreCoverLetterVersion(appID, letterID, versionID, userID string) (*models.CoverLetter, error) {
+	l, err := db.GetCoverLetter(appID, letterID, userID)
+	if err != nil || l == nil {
+		return nil, err
+	}
+	v, err := db.GetCoverLetterVersion(letterID, versionID)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return db.UpdateCoverLetter(appID, letterID, userID, models.UpdateCoverLetterRequest{Title: v.Title, Body: v.Body})
+}
+
+func scanCoverLetterVersion(s scanner) (models.CoverLetterVersion, error) {
+	var v models.CoverLetterVersion
+	var createdAt string
+	if err := s.Scan(&v.ID, &v.CoverLetterID, &v.Title, &v.Body, &v.Message, &createdAt); err != nil {
+		return v, err
+	}
+	v.CreatedAt, _ = parseTime(createdAt)
+	return v, nil
+}
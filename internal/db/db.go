@@ -4,9 +4,11 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/cv-forge/cv-forge/internal/schema"
 	"github.com/google/uuid"
 	_ "modernc.org/sqlite"
 )
@@ -73,6 +75,155 @@ func (db *DB) migrate() error {
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		)`,
+		`CREATE TABLE IF NOT EXISTS label_packs (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			name TEXT NOT NULL,
+			locale TEXT NOT NULL DEFAULT '',
+			labels TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS blobs (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			filename TEXT NOT NULL DEFAULT '',
+			content_type TEXT NOT NULL,
+			size INTEGER NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS application_attachments (
+			id TEXT PRIMARY KEY,
+			application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+			blob_id TEXT NOT NULL REFERENCES blobs(id) ON DELETE CASCADE,
+			kind TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS library_items (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			kind TEXT NOT NULL,
+			name TEXT NOT NULL DEFAULT '',
+			content TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS pipeline_stages (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			name TEXT NOT NULL,
+			position INTEGER NOT NULL,
+			terminal BOOLEAN NOT NULL DEFAULT 0,
+			color TEXT NOT NULL DEFAULT '',
+			category TEXT NOT NULL,
+			UNIQUE (user_id, name)
+		)`,
+		`CREATE TABLE IF NOT EXISTS application_events (
+			id TEXT PRIMARY KEY,
+			application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+			type TEXT NOT NULL,
+			field TEXT NOT NULL DEFAULT '',
+			from_value TEXT NOT NULL DEFAULT '',
+			to_value TEXT NOT NULL DEFAULT '',
+			note TEXT NOT NULL DEFAULT '',
+			occurred_at DATETIME NOT NULL,
+			created_at DATETIME NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_application_events_application ON application_events(application_id)`,
+		`CREATE TABLE IF NOT EXISTS interviews (
+			id TEXT PRIMARY KEY,
+			application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+			type TEXT NOT NULL,
+			starts_at DATETIME NOT NULL,
+			ends_at DATETIME NOT NULL,
+			timezone TEXT NOT NULL DEFAULT '',
+			location TEXT NOT NULL DEFAULT '',
+			video_link TEXT NOT NULL DEFAULT '',
+			interviewers TEXT NOT NULL DEFAULT '[]',
+			outcome TEXT NOT NULL,
+			notes TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS calendar_tokens (
+			user_id TEXT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+			token_hash TEXT NOT NULL UNIQUE,
+			created_at DATETIME NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS notifications_sent (
+			key TEXT PRIMARY KEY,
+			sent_at DATETIME NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS contacts (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			name TEXT NOT NULL,
+			company TEXT NOT NULL DEFAULT '',
+			role TEXT NOT NULL DEFAULT '',
+			email TEXT NOT NULL DEFAULT '',
+			phone TEXT NOT NULL DEFAULT '',
+			linkedin TEXT NOT NULL DEFAULT '',
+			notes TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS application_contacts (
+			application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+			contact_id TEXT NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+			relationship TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			PRIMARY KEY (application_id, contact_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS contact_interactions (
+			id TEXT PRIMARY KEY,
+			contact_id TEXT NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+			application_id TEXT REFERENCES applications(id) ON DELETE SET NULL,
+			type TEXT NOT NULL,
+			summary TEXT NOT NULL DEFAULT '',
+			occurred_at DATETIME NOT NULL,
+			created_at DATETIME NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS companies (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			name TEXT NOT NULL,
+			aliases TEXT NOT NULL DEFAULT '[]',
+			website TEXT NOT NULL DEFAULT '',
+			industry TEXT NOT NULL DEFAULT '',
+			size TEXT NOT NULL DEFAULT '',
+			location TEXT NOT NULL DEFAULT '',
+			notes TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS cover_letter_templates (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			name TEXT NOT NULL,
+			body TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS cover_letters (
+			id TEXT PRIMARY KEY,
+			application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+			template_id TEXT REFERENCES cover_letter_templates(id) ON DELETE SET NULL,
+			cv_id TEXT REFERENCES cvs(id) ON DELETE SET NULL,
+			cv_version_id TEXT REFERENCES cv_versions(id) ON DELETE SET NULL,
+			title TEXT NOT NULL,
+			body TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_cover_letters_application ON cover_letters(application_id)`,
+		`CREATE TABLE IF NOT EXISTS cover_letter_versions (
+			id TEXT PRIMARY KEY,
+			cover_letter_id TEXT NOT NULL REFERENCES cover_letters(id) ON DELETE CASCADE,
+			title TEXT NOT NULL,
+			body TEXT NOT NULL,
+			message TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL
+		)`,
 		// Add user_id to cvs if it doesn't exist.
 	}
 	for _, m := range migrations {
@@ -88,6 +239,33 @@ func (db *DB) migrate() error {
 	if err := db.addColumnIfNotExists("applications", "user_id", "TEXT REFERENCES users(id)"); err != nil {
 		return err
 	}
+	if err := db.addColumnIfNotExists("applications", "deadline", "DATETIME"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfNotExists("applications", "follow_up_at", "DATETIME"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfNotExists("applications", "company_id", "TEXT REFERENCES companies(id) ON DELETE SET NULL"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfNotExists("applications", "compensation", "TEXT"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfNotExists("applications", "source", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfNotExists("applications", "location", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := db.backfillCompensation(); err != nil {
+		return err
+	}
+	if err := db.backfillCompanies(); err != nil {
+		return err
+	}
+	if err := db.backfillEvents(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -141,6 +319,25 @@ func (db *DB) GetUser(id string) (*models.User, error) {
 	return &user, nil
 }
 
+// ListUsers returns all users.
+func (db *DB) ListUsers() ([]models.User, error) {
+	rows, err := db.conn.Query(`SELECT id, email, name, picture, created_at, updated_at FROM users ORDER BY created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		user, err := scanUserRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
 // GetUserByEmail returns a user by Email.
 func (db *DB) GetUserByEmail(email string) (*models.User, error) {
 	row := db.conn.QueryRow(`SELECT id, email, name, picture, created_at, updated_at FROM users WHERE email = ?`, email)
@@ -201,24 +398,61 @@ func (db *DB) CreateOrUpdateUser(email, name, picture string) (*models.User, err
 
 // ListCVs returns all CVs for a user.
 func (db *DB) ListCVs(userID string) ([]models.CV, error) {
-	rows, err := db.conn.Query(`SELECT id, title, data, created_at, updated_at FROM cvs WHERE user_id = ? ORDER BY updated_at DESC`, userID)
+	cvs, _, err := db.QueryCVs(userID, models.CVFilter{})
+	return cvs, err
+}
+
+// cvSorts are the fields CVs can be ordered by.
+var cvSorts = map[string]sortField{
+	"updatedAt": {expr: "COALESCE(updated_at, '')", desc: true},
+	"createdAt": {expr: "COALESCE(created_at, '')", desc: true},
+	"title":     {expr: "title COLLATE NOCASE"},
+}
+
+// QueryCVs returns the user's CVs matching f, most recently updated first
+// unless f says otherwise. With a limit it returns one page, and a cursor
+// for the next one if there is more.
+func (db *DB) QueryCVs(userID string, f models.CVFilter) ([]models.CV, string, error) {
+	page, err := newKeyset(cvSorts, "updatedAt", "id", f.ListOptions)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	conds := []string{"user_id = ?"}
+	args := []any{userID}
+	if cond, condArgs := likeTerms(f.Query, "title"); cond != "" {
+		conds = append(conds, cond)
+		args = append(args, condArgs...)
+	}
+	if cond, condArgs := page.where(); cond != "" {
+		conds = append(conds, cond)
+		args = append(args, condArgs...)
+	}
+
+	rows, err := db.conn.Query(
+		`SELECT id, title, data, created_at, updated_at, `+page.field.expr+`
+		FROM cvs WHERE `+strings.Join(conds, " AND ")+` `+page.orderBy(),
+		args...,
+	)
+	if err != nil {
+		return nil, "", err
 	}
 	defer rows.Close()
 
-	var cvs []models.CV
+	cvs := []models.CV{}
+	var key, lastKey any
 	for rows.Next() {
-		cv, err := scanCV(rows)
+		cv, err := scanCV(keyScanner{rows, &key})
 		if err != nil {
-			return nil, err
+			return nil, "", err
+		}
+		if page.full(len(cvs)) {
+			return cvs, page.next(lastKey, cvs[len(cvs)-1].ID), nil
 		}
 		cvs = append(cvs, cv)
+		lastKey = key
 	}
-	if cvs == nil {
-		cvs = []models.CV{}
-	}
-	return cvs, rows.Err()
+	return cvs, "", rows.Err()
 }
 
 // GetCV returns a single CV by ID, ensuring it belongs to the user (or is legacy global).
@@ -237,7 +471,7 @@ func (db *DB) GetCV(id, userID string) (*models.CV, error) {
 // CreateCV creates a new CV and returns it.
 func (db *DB) CreateCV(userID, title string, data models.CVData) (*models.CV, error) {
 	id := uuid.New().String()
-	dataJSON, err := json.Marshal(data)
+	dataJSON, err := encodeCVData(&data)
 	if err != nil {
 		return nil, err
 	}
@@ -260,7 +494,7 @@ func (db *DB) CreateCV(userID, title string, data models.CVData) (*models.CV, er
 
 // UpdateCV updates an existing CV.
 func (db *DB) UpdateCV(id, userID, title string, data models.CVData) (*models.CV, error) {
-	dataJSON, err := json.Marshal(data)
+	dataJSON, err := encodeCVData(&data)
 	if err != nil {
 		return nil, err
 	}
@@ -348,7 +582,7 @@ func (db *DB) CreateVersion(cvID, userID, message string) (*models.CVVersion, er
 	}
 
 	id := uuid.New().String()
-	dataJSON, err := json.Marshal(cv.Data)
+	dataJSON, err := encodeCVData(&cv.Data)
 	if err != nil {
 		return nil, err
 	}
@@ -372,7 +606,7 @@ func (db *DB) CreateVersion(cvID, userID, message string) (*models.CVVersion, er
 // CreateVersionFromData creates a version from explicit data (used for import). We trust caller checked auth.
 func (db *DB) CreateVersionFromData(cvID, message string, data models.CVData) (*models.CVVersion, error) {
 	id := uuid.New().String()
-	dataJSON, err := json.Marshal(data)
+	dataJSON, err := encodeCVData(&data)
 	if err != nil {
 		return nil, err
 	}
@@ -416,11 +650,26 @@ func (db *DB) RestoreVersion(cvID, versionID, userID string) (*models.CV, error)
 
 // --- Scan helpers ---
 
+// encodeCVData stamps data with the current schema version and marshals it.
+func encodeCVData(data *models.CVData) ([]byte, error) {
+	data.SchemaVersion = schema.Current
+	return json.Marshal(data)
+}
+
+// decodeCVData upgrades stored CV JSON to the current schema and decodes it.
+func decodeCVData(s string, data *models.CVData) error {
+	raw, err := schema.Upgrade([]byte(s))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, data)
+}
+
 type scanner interface {
 	Scan(dest ...any) error
 }
 
-func scanUserRow(row *sql.Row) (models.User, error) {
+func scanUserRow(row scanner) (models.User, error) {
 	var u models.User
 	var createdAt, updatedAt string
 	err := row.Scan(&u.ID, &u.Email, &u.Name, &u.Picture, &createdAt, &updatedAt)
@@ -446,7 +695,7 @@ func scanCV(s interface{ Scan(...any) error }) (models.CV, error) {
 	if err != nil {
 		return cv, err
 	}
-	if err := json.Unmarshal([]byte(dataStr), &cv.Data); err != nil {
+	if err := decodeCVData(dataStr, &cv.Data); err != nil {
 		return cv, fmt.Errorf("unmarshal cv data: %w", err)
 	}
 	cv.CreatedAt, _ = time.Parse("2006-01-02 15:04:05+00:00", createdAt)
@@ -468,7 +717,7 @@ func scanCVRow(row *sql.Row) (models.CV, error) {
 	if err != nil {
 		return cv, err
 	}
-	if err := json.Unmarshal([]byte(dataStr), &cv.Data); err != nil {
+	if err := decodeCVData(dataStr, &cv.Data); err != nil {
 		return cv, fmt.Errorf("unmarshal cv data: %w", err)
 	}
 	cv.CreatedAt, _ = time.Parse("2006-01-02 15:04:05+00:00", createdAt)
@@ -490,7 +739,7 @@ func scanVersion(s interface{ Scan(...any) error }) (models.CVVersion, error) {
 	if err != nil {
 		return v, err
 	}
-	if err := json.Unmarshal([]byte(dataStr), &v.Data); err != nil {
+	if err := decodeCVData(dataStr, &v.Data); err != nil {
 		return v, fmt.Errorf("unmarshal version data: %w", err)
 	}
 	v.CreatedAt, _ = time.Parse("2006-01-02 15:04:05+00:00", createdAt)
@@ -508,7 +757,7 @@ func scanVersionRow(row *sql.Row) (models.CVVersion, error) {
 	if err != nil {
 		return v, err
 	}
-	if err := json.Unmarshal([]byte(dataStr), &v.Data); err != nil {
+	if err := decodeCVData(dataStr, &v.Data); err != nil {
 		return v, fmt.Errorf("unmarshal version data: %w", err)
 	}
 	v.CreatedAt, _ = time.Parse("2006-01-02 15:04:05+00:00", createdAt)
@@ -0,0 +1,227 @@
+package db
+
+import (
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/google/uuid"
+)
+
+// execer is satisfied by both *sql.DB and *sql.Tx.
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// ListEvents returns the timeline events of an application, oldest first.
+// It returns nil if the application does not exist.
+func (db *DB) ListEvents(appID, userID string) ([]models.ApplicationEvent, error) {
+	app, err := db.GetApplication(appID, userID)
+	if err != nil || app == nil {
+		return nil, err
+	}
+
+	rows, err := db.conn.Query(
+		`SELECT id, application_id, type, field, from_value, to_value, note, occurred_at, created_at
+		FROM application_events WHERE application_id = ?`,
+		appID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.ApplicationEvent{}
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].OccurredAt.Equal(events[j].OccurredAt) {
+			return events[i].CreatedAt.Before(events[j].CreatedAt)
+		}
+		return events[i].OccurredAt.Before(events[j].OccurredAt)
+	})
+	return events, nil
+}
+
+// CreateEvent logs a manual event on an application. It returns nil if the
+// application does not exist.
+func (db *DB) CreateEvent(appID, userID string, req models.CreateEventRequest) (*models.ApplicationEvent, error) {
+	app, err := db.GetApplication(appID, userID)
+	if err != nil || app == nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	e := models.ApplicationEvent{
+		ID:            uuid.New().String(),
+		ApplicationID: appID,
+		Type:          req.Type,
+		Note:          req.Note,
+		OccurredAt:    now,
+		CreatedAt:     now,
+	}
+	if req.OccurredAt != nil {
+		e.OccurredAt = req.OccurredAt.UTC()
+	}
+	if err := insertEvent(db.conn, e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// DeleteEvent removes a manual event. Recorded changes cannot be deleted.
+func (db *DB) DeleteEvent(appID, id, userID string) (bool, error) {
+	res, err := db.conn.Exec(
+		`DELETE FROM application_events
+		WHERE id = ? AND application_id = ? AND type IN (?, ?, ?, ?)
+		AND application_id IN (SELECT id FROM applications WHERE user_id = ? OR user_id IS NULL)`,
+		id, appID, models.EventCall, models.EventEmail, models.EventInterview, models.EventNote, userID,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func insertEvent(x execer, e models.ApplicationEvent) error {
+	_, err := x.Exec(
+		`INSERT INTO application_events (id, application_id, type, field, from_value, to_value, note, occurred_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		e.ID, e.ApplicationID, e.Type, e.Field, e.From, e.To, e.Note, e.OccurredAt, e.CreatedAt,
+	)
+	return err
+}
+
+// applicationChanges returns the events recording the differences between
+// the stored application and an update.
+func applicationChanges(old models.Application, req models.UpdateApplicationRequest, now time.Time) []models.ApplicationEvent {
+	var events []models.ApplicationEvent
+	add := func(typ models.EventType, field, from, to string) {
+		events = append(events, models.ApplicationEvent{
+			ID:            uuid.New().String(),
+			ApplicationID: old.ID,
+			Type:          typ,
+			Field:         field,
+			From:          from,
+			To:            to,
+			OccurredAt:    now,
+			CreatedAt:     now,
+		})
+	}
+	field := func(name, from, to string) {
+		if from != to {
+			add(models.EventFieldChange, name, from, to)
+		}
+	}
+
+	if old.Status != req.Status {
+		add(models.EventStatusChange, "status", string(old.Status), string(req.Status))
+	}
+	field("companyId", deref(old.CompanyID), deref(req.CompanyID))
+	field("company", old.Company, req.Company)
+	field("role", old.Role, req.Role)
+	field("compensation", formatCompensation(old.Compensation), formatCompensation(req.Compensation))
+	field("url", old.URL, req.URL)
+	field("source", old.Source, req.Source)
+	field("location", old.Location, req.Location)
+	field("date", formatDate(old.Date), formatDate(req.Date))
+	field("deadline", formatDatePtr(old.Deadline), formatDatePtr(req.Deadline))
+	field("followUpAt", formatDatePtr(old.FollowUpAt), formatDatePtr(req.FollowUpAt))
+	field("cvId", deref(old.CVID), deref(req.CVID))
+	field("cvVersionId", deref(old.CVVersionID), deref(req.CVVersionID))
+	// Notes can be long; record that they changed, not the text
+	if old.Notes != req.Notes {
+		add(models.EventFieldChange, "notes", "", "")
+	}
+	return events
+}
+
+// backfillEvents gives applications created before the timeline existed a
+// created event, so their first stage has a start.
+func (db *DB) backfillEvents() error {
+	rows, err := db.conn.Query(
+		`SELECT id, status, created_at FROM applications a
+		WHERE NOT EXISTS (SELECT 1 FROM application_events e WHERE e.application_id = a.id)`,
+	)
+	if err != nil {
+		return err
+	}
+	var events []models.ApplicationEvent
+	for rows.Next() {
+		var id, status, createdAt string
+		if err := rows.Scan(&id, &status, &createdAt); err != nil {
+			rows.Close()
+			return err
+		}
+		at, _ := parseTime(createdAt)
+		events = append(events, models.ApplicationEvent{
+			ID:            uuid.New().String(),
+			ApplicationID: id,
+			Type:          models.EventCreated,
+			To:            status,
+			OccurredAt:    at,
+			CreatedAt:     at,
+		})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		if err := insertEvent(db.conn, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanEvent(s scanner) (models.ApplicationEvent, error) {
+	var e models.ApplicationEvent
+	var occurredAt, createdAt string
+	err := s.Scan(&e.ID, &e.ApplicationID, &e.Type, &e.Field, &e.From, &e.To, &e.Note, &occurredAt, &createdAt)
+	if err != nil {
+		return e, err
+	}
+	e.OccurredAt, _ = parseTime(occurredAt)
+	e.CreatedAt, _ = parseTime(createdAt)
+	return e, nil
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func formatDatePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatDate(*t)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func formatCompensation(c *models.Compensation) string {
+	if c == nil {
+		return ""
+	}
+	return c.String()
+}
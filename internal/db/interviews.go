@@ -0,0 +1,160 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/google/uuid"
+)
+
+const interviewColumns = `i.id, i.application_id, a.company, a.role, i.type, i.starts_at, i.ends_at, i.timezone,
+	i.location, i.video_link, i.interviewers, i.outcome, i.notes, i.created_at, i.updated_at`
+
+// ListInterviews returns the interviews of an application by start time.
+func (db *DB) ListInterviews(appID, userID string) ([]models.Interview, error) {
+	return db.queryInterviews(
+		`SELECT `+interviewColumns+`
+		FROM interviews i
+		JOIN applications a ON a.id = i.application_id
+		WHERE i.application_id = ? AND (a.user_id = ? OR a.user_id IS NULL)
+		ORDER BY i.starts_at`,
+		appID, userID,
+	)
+}
+
+// ListUpcomingInterviews returns the user's interviews that end after
+// from, across all applications, by start time. Cancelled interviews are
+// included so calendars can show them as such.
+func (db *DB) ListUpcomingInterviews(userID string, from time.Time) ([]models.Interview, error) {
+	return db.queryInterviews(
+		`SELECT `+interviewColumns+`
+		FROM interviews i
+		JOIN applications a ON a.id = i.application_id
+		WHERE (a.user_id = ? OR a.user_id IS NULL) AND i.ends_at >= ?
+		ORDER BY i.starts_at`,
+		userID, from.UTC(),
+	)
+}
+
+// GetInterview returns a single interview of an application.
+func (db *DB) GetInterview(appID, id, userID string) (*models.Interview, error) {
+	row := db.conn.QueryRow(
+		`SELECT `+interviewColumns+`
+		FROM interviews i
+		JOIN applications a ON a.id = i.application_id
+		WHERE i.id = ? AND i.application_id = ? AND (a.user_id = ? OR a.user_id IS NULL)`,
+		id, appID, userID,
+	)
+	iv, err := scanInterview(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &iv, nil
+}
+
+// CreateInterview schedules an interview. It returns nil if the application
+// does not exist.
+func (db *DB) CreateInterview(appID, userID string, req models.InterviewRequest) (*models.Interview, error) {
+	app, err := db.GetApplication(appID, userID)
+	if err != nil || app == nil {
+		return nil, err
+	}
+	interviewers, err := json.Marshal(req.Interviewers)
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New().String()
+	now := time.Now().UTC()
+	_, err = db.conn.Exec(
+		`INSERT INTO interviews (id, application_id, type, starts_at, ends_at, timezone, location, video_link, interviewers, outcome, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, appID, req.Type, req.StartsAt.UTC(), req.EndsAt.UTC(), req.Timezone, req.Location, req.VideoLink,
+		string(interviewers), req.Outcome, req.Notes, now, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return db.GetInterview(appID, id, userID)
+}
+
+// UpdateInterview replaces an interview's details.
+func (db *DB) UpdateInterview(appID, id, userID string, req models.InterviewRequest) (*models.Interview, error) {
+	interviewers, err := json.Marshal(req.Interviewers)
+	if err != nil {
+		return nil, err
+	}
+	res, err := db.conn.Exec(
+		`UPDATE interviews
+		SET type = ?, starts_at = ?, ends_at = ?, timezone = ?, location = ?, video_link = ?, interviewers = ?, outcome = ?, notes = ?, updated_at = ?
+		WHERE id = ? AND application_id = ?
+		AND application_id IN (SELECT id FROM applications WHERE user_id = ? OR user_id IS NULL)`,
+		req.Type, req.StartsAt.UTC(), req.EndsAt.UTC(), req.Timezone, req.Location, req.VideoLink,
+		string(interviewers), req.Outcome, req.Notes, time.Now().UTC(), id, appID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return nil, nil
+	}
+	return db.GetInterview(appID, id, userID)
+}
+
+// DeleteInterview removes an interview.
+func (db *DB) DeleteInterview(appID, id, userID string) (bool, error) {
+	res, err := db.conn.Exec(
+		`DELETE FROM interviews WHERE id = ? AND application_id = ?
+		AND application_id IN (SELECT id FROM applications WHERE user_id = ? OR user_id IS NULL)`,
+		id, appID, userID,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func (db *DB) queryInterviews(query string, args ...any) ([]models.Interview, error) {
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	interviews := []models.Interview{}
+	for rows.Next() {
+		iv, err := scanInterview(rows)
+		if err != nil {
+			return nil, err
+		}
+		interviews = append(interviews, iv)
+	}
+	return interviews, rows.Err()
+}
+
+func scanInterview(s scanner) (models.Interview, error) {
+	var iv models.Interview
+	var startsAt, endsAt, interviewers, createdAt, updatedAt string
+	err := s.Scan(
+		&iv.ID, &iv.ApplicationID, &iv.Company, &iv.Role, &iv.Type, &startsAt, &endsAt, &iv.Timezone,
+		&iv.Location, &iv.VideoLink, &interviewers, &iv.Outcome, &iv.Notes, &createdAt, &updatedAt,
+	)
+	if err != nil {
+		return iv, err
+	}
+	if err := json.Unmarshal([]byte(interviewers), &iv.Interviewers); err != nil || iv.Interviewers == nil {
+		iv.Interviewers = []string{}
+	}
+	iv.StartsAt, _ = parseTime(startsAt)
+	iv.EndsAt, _ = parseTime(endsAt)
+	iv.CreatedAt, _ = parseTime(createdAt)
+	iv.UpdatedAt, _ = parseTime(updatedAt)
+	return iv, nil
+}
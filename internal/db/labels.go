@@ -0,0 +1,121 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/google/uuid"
+)
+
+// ListLabelPacks returns the user's own label packs.
+func (db *DB) ListLabelPacks(userID string) ([]models.LabelPack, error) {
+	rows, err := db.conn.Query(
+		`SELECT id, name, locale, labels, created_at, updated_at FROM label_packs WHERE user_id = ? ORDER BY name`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var packs []models.LabelPack
+	for rows.Next() {
+		p, err := scanLabelPack(rows)
+		if err != nil {
+			return nil, err
+		}
+		packs = append(packs, p)
+	}
+	if packs == nil {
+		packs = []models.LabelPack{}
+	}
+	return packs, rows.Err()
+}
+
+// GetLabelPack returns a user label pack by ID.
+func (db *DB) GetLabelPack(id, userID string) (*models.LabelPack, error) {
+	row := db.conn.QueryRow(
+		`SELECT id, name, locale, labels, created_at, updated_at FROM label_packs WHERE id = ? AND user_id = ?`,
+		id, userID,
+	)
+	p, err := scanLabelPack(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// CreateLabelPack stores a new user label pack.
+func (db *DB) CreateLabelPack(userID string, req models.LabelPackRequest) (*models.LabelPack, error) {
+	labelsJSON, err := json.Marshal(req.Labels)
+	if err != nil {
+		return nil, err
+	}
+	id := uuid.New().String()
+	now := time.Now().UTC()
+	_, err = db.conn.Exec(
+		`INSERT INTO label_packs (id, user_id, name, locale, labels, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		id, userID, req.Name, req.Locale, string(labelsJSON), now, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &models.LabelPack{
+		ID:        id,
+		Name:      req.Name,
+		Locale:    req.Locale,
+		Labels:    req.Labels,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// UpdateLabelPack updates a user label pack.
+func (db *DB) UpdateLabelPack(id, userID string, req models.LabelPackRequest) (*models.LabelPack, error) {
+	labelsJSON, err := json.Marshal(req.Labels)
+	if err != nil {
+		return nil, err
+	}
+	res, err := db.conn.Exec(
+		`UPDATE label_packs SET name = ?, locale = ?, labels = ?, updated_at = ? WHERE id = ? AND user_id = ?`,
+		req.Name, req.Locale, string(labelsJSON), time.Now().UTC(), id, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return nil, nil
+	}
+	return db.GetLabelPack(id, userID)
+}
+
+// DeleteLabelPack deletes a user label pack.
+func (db *DB) DeleteLabelPack(id, userID string) (bool, error) {
+	res, err := db.conn.Exec(`DELETE FROM label_packs WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func scanLabelPack(s scanner) (models.LabelPack, error) {
+	var p models.LabelPack
+	var labelsStr, createdAt, updatedAt string
+	if err := s.Scan(&p.ID, &p.Name, &p.Locale, &labelsStr, &createdAt, &updatedAt); err != nil {
+		return p, err
+	}
+	if err := json.Unmarshal([]byte(labelsStr), &p.Labels); err != nil {
+		return p, fmt.Errorf("unmarshal label pack: %w", err)
+	}
+	p.CreatedAt, _ = parseTime(createdAt)
+	p.UpdatedAt, _ = parseTime(updatedAt)
+	return p, nil
+}
@@ -0,0 +1,275 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/google/uuid"
+)
+
+// queryer is satisfied by both *sql.DB and *sql.Tx.
+type queryer interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// ListLibraryItems returns the user's library items, optionally of one kind.
+func (db *DB) ListLibraryItems(userID string, kind models.LibraryKind) ([]models.LibraryItem, error) {
+	return listLibraryItems(db.conn, userID, kind)
+}
+
+func listLibraryItems(q queryer, userID string, kind models.LibraryKind) ([]models.LibraryItem, error) {
+	rows, err := q.Query(
+		`SELECT id, kind, name, content, created_at, updated_at FROM library_items
+		WHERE user_id = ? AND (? = '' OR kind = ?)
+		ORDER BY kind, name`,
+		userID, kind, kind,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.LibraryItem
+	for rows.Next() {
+		item, err := scanLibraryItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if items == nil {
+		items = []models.LibraryItem{}
+	}
+	return items, rows.Err()
+}
+
+// LibraryItemMap returns all of the user's library items keyed by ID.
+func (db *DB) LibraryItemMap(userID string) (map[string]models.LibraryItem, error) {
+	return libraryItemMap(db.conn, userID)
+}
+
+func libraryItemMap(q queryer, userID string) (map[string]models.LibraryItem, error) {
+	items, err := listLibraryItems(q, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]models.LibraryItem, len(items))
+	for _, item := range items {
+		m[item.ID] = item
+	}
+	return m, nil
+}
+
+// GetLibraryItem returns a single library item.
+func (db *DB) GetLibraryItem(id, userID string) (*models.LibraryItem, error) {
+	row := db.conn.QueryRow(
+		`SELECT id, kind, name, content, created_at, updated_at FROM library_items WHERE id = ? AND user_id = ?`,
+		id, userID,
+	)
+	item, err := scanLibraryItem(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// CreateLibraryItem stores a new library item.
+func (db *DB) CreateLibraryItem(userID string, req models.LibraryItemRequest) (*models.LibraryItem, error) {
+	contentJSON, err := json.Marshal(req.Content)
+	if err != nil {
+		return nil, err
+	}
+	id := uuid.New().String()
+	now := time.Now().UTC()
+	_, err = db.conn.Exec(
+		`INSERT INTO library_items (id, user_id, kind, name, content, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		id, userID, req.Kind, req.Name, string(contentJSON), now, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &models.LibraryItem{
+		ID:        id,
+		Kind:      req.Kind,
+		Name:      req.Name,
+		Content:   req.Content,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// UpdateLibraryItem updates the name and content of a library item. Its
+// kind cannot change, since CVs use it in a slot of that kind. The new
+// content is copied into every CV using the item in the same transaction,
+// so either all of them follow the edit or none does.
+func (db *DB) UpdateLibraryItem(id, userID string, req models.LibraryItemRequest) (*models.LibraryItem, error) {
+	contentJSON, err := json.Marshal(req.Content)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	res, err := tx.Exec(
+		`UPDATE library_items SET name = ?, content = ?, updated_at = ? WHERE id = ? AND user_id = ? AND kind = ?`,
+		req.Name, string(contentJSON), now, id, userID, req.Kind,
+	)
+	if err != nil {
+		return nil, err
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return nil, nil
+	}
+
+	cvs, err := cvsReferencing(tx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(cvs) > 0 {
+		items, err := libraryItemMap(tx, userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, cv := range cvs {
+			models.ApplyLibrary(&cv.Data, items)
+			dataJSON, err := encodeCVData(&cv.Data)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := tx.Exec(
+				`UPDATE cvs SET data = ?, updated_at = ? WHERE id = ? AND user_id = ?`,
+				string(dataJSON), now, cv.ID, userID,
+			); err != nil {
+				return nil, fmt.Errorf("propagate library item to CV %s: %w", cv.ID, err)
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return db.GetLibraryItem(id, userID)
+}
+
+// DeleteLibraryItem deletes a library item. CVs and library experience
+// entries using it keep their copy of its content but stop following it.
+// This happens in one transaction, so no reference is left half detached.
+func (db *DB) DeleteLibraryItem(id, userID string) (bool, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`DELETE FROM library_items WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return false, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return false, nil
+	}
+
+	now := time.Now().UTC()
+	cvs, err := cvsReferencing(tx, id, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, cv := range cvs {
+		models.DetachLibrary(&cv.Data, id)
+		dataJSON, err := encodeCVData(&cv.Data)
+		if err != nil {
+			return false, err
+		}
+		if _, err := tx.Exec(
+			`UPDATE cvs SET data = ?, updated_at = ? WHERE id = ? AND user_id = ?`,
+			string(dataJSON), now, cv.ID, userID,
+		); err != nil {
+			return false, fmt.Errorf("detach library item from CV %s: %w", cv.ID, err)
+		}
+	}
+
+	// Library experience entries may use the item as one of their bullets
+	entries, err := listLibraryItems(tx, userID, models.LibraryExperience)
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range entries {
+		if entry.Content.Experience == nil {
+			continue
+		}
+		data := models.CVData{Experience: []models.Experience{*entry.Content.Experience}}
+		if len(models.LibraryRefs(data)[id]) == 0 {
+			continue
+		}
+		models.DetachLibrary(&data, id)
+		entry.Content.Experience = &data.Experience[0]
+		contentJSON, err := json.Marshal(entry.Content)
+		if err != nil {
+			return false, err
+		}
+		if _, err := tx.Exec(
+			`UPDATE library_items SET content = ?, updated_at = ? WHERE id = ? AND user_id = ?`,
+			string(contentJSON), now, entry.ID, userID,
+		); err != nil {
+			return false, fmt.Errorf("detach library item from %s: %w", entry.ID, err)
+		}
+	}
+	return true, tx.Commit()
+}
+
+// ListCVsReferencing returns the user's CVs whose data references the
+// library item. The text match is only a prefilter; callers confirm with
+// models.LibraryRefs.
+func (db *DB) ListCVsReferencing(itemID, userID string) ([]models.CV, error) {
+	return cvsReferencing(db.conn, itemID, userID)
+}
+
+func cvsReferencing(q queryer, itemID, userID string) ([]models.CV, error) {
+	rows, err := q.Query(
+		`SELECT id, title, data, created_at, updated_at FROM cvs WHERE user_id = ? AND instr(data, ?) > 0 ORDER BY updated_at DESC`,
+		userID, itemID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cvs []models.CV
+	for rows.Next() {
+		cv, err := scanCV(rows)
+		if err != nil {
+			return nil, err
+		}
+		if len(models.LibraryRefs(cv.Data)[itemID]) > 0 {
+			cvs = append(cvs, cv)
+		}
+	}
+	if cvs == nil {
+		cvs = []models.CV{}
+	}
+	return cvs, rows.Err()
+}
+
+func scanLibraryItem(s scanner) (models.LibraryItem, error) {
+	var item models.LibraryItem
+	var contentStr, createdAt, updatedAt string
+	if err := s.Scan(&item.ID, &item.Kind, &item.Name, &contentStr, &createdAt, &updatedAt); err != nil {
+		return item, err
+	}
+	if err := json.Unmarshal([]byte(contentStr), &item.Content); err != nil {
+		return item, fmt.Errorf("unmarshal library item: %w", err)
+	}
+	item.CreatedAt, _ = parseTime(createdAt)
+	item.UpdatedAt, _ = parseTime(updatedAt)
+	return item, nil
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+func TestDeleteLibraryItem(t *testing.T) {
+	d, u := newTestDB(t)
+	bullet, err := d.CreateLibraryItem(u.ID, models.LibraryItemRequest{
+		Kind: models.LibraryBullet, Name: "Latency", Content: models.LibraryContent{Text: "Cut latency by half"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := models.Experience{Company: "Acme", Title: "Engineer", Bullets: []models.Bullet{
+		{Text: "Cut latency by half", Ref: bullet.ID},
+		{Text: "Ran the on-call rota"},
+	}}
+	entry, err := d.CreateLibraryItem(u.ID, models.LibraryItemRequest{
+		Kind: models.LibraryExperience, Name: "Acme", Content: models.LibraryContent{Experience: &exp},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cvExp := exp
+	cvExp.Ref = entry.ID
+	cv, err := d.CreateCV(u.ID, "Backend", models.CVData{Experience: []models.Experience{cvExp}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := d.CreateOrUpdateUser("bob@example.com", "Bob Ray", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted, err := d.DeleteLibraryItem(bullet.ID, other.ID); deleted || err != nil {
+		t.Fatalf("DeleteLibraryItem() by another user = %v, %v, want false, nil", deleted, err)
+	}
+	if got, err := d.GetCV(cv.ID, u.ID); err != nil || got.Data.Experience[0].Bullets[0].Ref != bullet.ID {
+		t.Fatalf("a refused delete detached the CV: %+v, %v", got, err)
+	}
+
+	if deleted, err := d.DeleteLibraryItem(bullet.ID, u.ID); !deleted || err != nil {
+		t.Fatalf("DeleteLibraryItem() = %v, %v, want true, nil", deleted, err)
+	}
+	if item, err := d.GetLibraryItem(bullet.ID, u.ID); item != nil || err != nil {
+		t.Errorf("deleted item = %+v, %v", item, err)
+	}
+
+	// The CV and the experience entry keep the text but no longer follow the item
+	got, err := d.GetCV(cv.ID, u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := got.Data.Experience[0].Bullets[0]; b.Ref != "" || b.Text != "Cut latency by half" {
+		t.Errorf("CV bullet = %+v, want the text without a ref", b)
+	}
+	if got.Data.Experience[0].Ref != entry.ID {
+		t.Errorf("CV experience ref = %q, want it still to follow %s", got.Data.Experience[0].Ref, entry.ID)
+	}
+	e, err := d.GetLibraryItem(entry.ID, u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := e.Content.Experience.Bullets[0]; b.Ref != "" || b.Text != "Cut latency by half" {
+		t.Errorf("library entry bullet = %+v, want the text without a ref", b)
+	}
+}
@@ -0,0 +1,158 @@
+package db
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// ErrInvalidList is returned for list options that cannot apply, such as
+// an unknown sort field or a cursor from a different ordering.
+var ErrInvalidList = errors.New("invalid list options")
+
+// sortField is a field a list can be ordered by.
+type sortField struct {
+	expr string // SQL expression of the sort key; never NULL
+	desc bool   // Default direction
+}
+
+// keyset pages through a list ordered by a sort key and then by ID, so
+// that the order is stable and a page starts right after the row the
+// previous one ended on, whatever was added or removed since.
+type keyset struct {
+	sort  string
+	field sortField
+	id    string // SQL expression of the row ID
+	desc  bool
+	limit int
+	after *cursor
+}
+
+// cursor is where a page ended. It is handed out opaque.
+type cursor struct {
+	Sort string `json:"s"`
+	Desc bool   `json:"d"`
+	Key  any    `json:"k"`
+	ID   string `json:"id"`
+}
+
+func newKeyset(fields map[string]sortField, def, id string, opts models.ListOptions) (*keyset, error) {
+	k := &keyset{sort: opts.Sort, id: id, limit: opts.Limit}
+	if k.sort == "" {
+		k.sort = def
+	}
+	f, ok := fields[k.sort]
+	if !ok {
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("%w: sort must be one of: %s", ErrInvalidList, strings.Join(names, ", "))
+	}
+	k.field, k.desc = f, f.desc
+	switch opts.Order {
+	case "":
+	case "asc":
+		k.desc = false
+	case "desc":
+		k.desc = true
+	default:
+		return nil, fmt.Errorf("%w: order must be asc or desc", ErrInvalidList)
+	}
+	if k.limit < 0 || k.limit > models.MaxListLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidList, models.MaxListLimit)
+	}
+
+	if opts.Cursor != "" {
+		b, err := base64.RawURLEncoding.DecodeString(opts.Cursor)
+		var c cursor
+		if err != nil || json.Unmarshal(b, &c) != nil || c.ID == "" || c.Key == nil {
+			return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidList)
+		}
+		if c.Sort != k.sort || c.Desc != k.desc {
+			return nil, fmt.Errorf("%w: cursor is for a different sort order", ErrInvalidList)
+		}
+		k.after = &c
+	}
+	return k, nil
+}
+
+// where returns the condition selecting the rows after the cursor, or ""
+// on the first page.
+func (k *keyset) where() (string, []any) {
+	if k.after == nil {
+		return "", nil
+	}
+	op := ">"
+	if k.desc {
+		op = "<"
+	}
+	cond := fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND %[3]s %[2]s ?))", k.field.expr, op, k.id)
+	return cond, []any{k.after.Key, k.after.Key, k.after.ID}
+}
+
+// orderBy returns the ORDER BY and LIMIT clauses. One row more than a page
+// is fetched, to tell whether there is a next page.
+func (k *keyset) orderBy() string {
+	dir := "ASC"
+	if k.desc {
+		dir = "DESC"
+	}
+	s := fmt.Sprintf("ORDER BY %s %s, %s %s", k.field.expr, dir, k.id, dir)
+	if k.limit > 0 {
+		s += fmt.Sprintf(" LIMIT %d", k.limit+1)
+	}
+	return s
+}
+
+// full reports whether n rows make a page, so that a further row means
+// there is a next page.
+func (k *keyset) full(n int) bool {
+	return k.limit > 0 && n >= k.limit
+}
+
+// next returns the cursor of a page that ended on the row with the given
+// sort key and ID.
+func (k *keyset) next(key any, id string) string {
+	b, _ := json.Marshal(cursor{Sort: k.sort, Desc: k.desc, Key: key, ID: id})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// keyScanner scans a row whose sort key follows the columns a scanner
+// function knows about.
+type keyScanner struct {
+	s   scanner
+	key *any
+}
+
+func (k keyScanner) Scan(dest ...any) error {
+	return k.s.Scan(append(dest, k.key)...)
+}
+
+// likeTerms returns a condition that each word of q appears in one of the
+// columns, case-insensitively, and its arguments.
+func likeTerms(q string, columns ...string) (string, []any) {
+	var conds []string
+	var args []any
+	for _, word := range strings.Fields(q) {
+		pattern := "%" + escapeLike(word) + "%"
+		var ors []string
+		for _, c := range columns {
+			ors = append(ors, "COALESCE("+c+", '') LIKE ? ESCAPE '\\'")
+			args = append(args, pattern)
+		}
+		conds = append(conds, "("+strings.Join(ors, " OR ")+")")
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
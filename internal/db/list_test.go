@@ -0,0 +1,173 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+var testSorts = map[string]sortField{
+	"date": {expr: "x.date", desc: true},
+	"name": {expr: "x.name"},
+}
+
+func TestNewKeyset(t *testing.T) {
+	dateCursor := (&keyset{sort: "date", desc: true}).next("2025-03-04", "a1")
+	tests := []struct {
+		name     string
+		opts     models.ListOptions
+		wantSort string
+		wantDesc bool
+	}{
+		{"default", models.ListOptions{}, "date", true},
+		{"default direction", models.ListOptions{Sort: "name"}, "name", false},
+		{"order", models.ListOptions{Sort: "date", Order: "asc"}, "date", false},
+		{"cursor", models.ListOptions{Limit: 10, Cursor: dateCursor}, "date", true},
+	}
+	for _, tt := range tests {
+		k, err := newKeyset(testSorts, "date", "x.id", tt.opts)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if k.sort != tt.wantSort || k.desc != tt.wantDesc {
+			t.Errorf("%s: sort %s desc %v, want %s desc %v", tt.name, k.sort, k.desc, tt.wantSort, tt.wantDesc)
+		}
+	}
+}
+
+func TestNewKeysetErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts models.ListOptions
+	}{
+		{"unknown sort", models.ListOptions{Sort: "salary"}},
+		{"bad order", models.ListOptions{Order: "up"}},
+		{"negative limit", models.ListOptions{Limit: -1}},
+		{"limit too large", models.ListOptions{Limit: models.MaxListLimit + 1}},
+		{"not base64", models.ListOptions{Cursor: "!!"}},
+		{"not JSON", models.ListOptions{Cursor: "bm9wZQ"}},
+		{"no ID", models.ListOptions{Cursor: (&keyset{sort: "date", desc: true}).next("2025-03-04", "")}},
+		{"other sort", models.ListOptions{Sort: "name", Cursor: (&keyset{sort: "date", desc: true}).next("2025-03-04", "a1")}},
+		{"other order", models.ListOptions{Order: "asc", Cursor: (&keyset{sort: "date", desc: true}).next("2025-03-04", "a1")}},
+	}
+	for _, tt := range tests {
+		if _, err := newKeyset(testSorts, "date", "x.id", tt.opts); !errors.Is(err, ErrInvalidList) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, ErrInvalidList)
+		}
+	}
+}
+
+func TestKeysetClauses(t *testing.T) {
+	k, err := newKeyset(testSorts, "date", "x.id", models.ListOptions{Limit: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cond, args := k.where(); cond != "" || args != nil {
+		t.Errorf("where() on the first page = %q %v, want none", cond, args)
+	}
+	if got, want := k.orderBy(), "ORDER BY x.date DESC, x.id DESC LIMIT 21"; got != want {
+		t.Errorf("orderBy() = %q, want %q", got, want)
+	}
+	if k.full(19) || !k.full(20) {
+		t.Error("full() should hold from 20 rows")
+	}
+
+	k, err = newKeyset(testSorts, "date", "x.id", models.ListOptions{Limit: 20, Cursor: k.next("2025-03-04", "a1")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cond, args := k.where()
+	if want := "(x.date < ? OR (x.date = ? AND x.id < ?))"; cond != want {
+		t.Errorf("where() = %q, want %q", cond, want)
+	}
+	if want := []any{"2025-03-04", "2025-03-04", "a1"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("where() args = %v, want %v", args, want)
+	}
+
+	k, err = newKeyset(testSorts, "date", "x.id", models.ListOptions{Sort: "name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := k.orderBy(), "ORDER BY x.name ASC, x.id ASC"; got != want {
+		t.Errorf("orderBy() without a limit = %q, want %q", got, want)
+	}
+	if k.full(1000) {
+		t.Error("full() without a limit should never hold")
+	}
+}
+
+func TestLikeTerms(t *testing.T) {
+	cond, args := likeTerms("  go 100%_ ", "a.role", "a.notes")
+	want := `(COALESCE(a.role, '') LIKE ? ESCAPE '\' OR COALESCE(a.notes, '') LIKE ? ESCAPE '\') AND ` +
+		`(COALESCE(a.role, '') LIKE ? ESCAPE '\' OR COALESCE(a.notes, '') LIKE ? ESCAPE '\')`
+	if cond != want {
+		t.Errorf("likeTerms() = %q, want %q", cond, want)
+	}
+	if want := []any{"%go%", "%go%", `%100\%\_%`, `%100\%\_%`}; !reflect.DeepEqual(args, want) {
+		t.Errorf("likeTerms() args = %q, want %q", args, want)
+	}
+	if cond, args := likeTerms(" "); cond != "" || args != nil {
+		t.Errorf("likeTerms of blank text = %q %v, want none", cond, args)
+	}
+}
+
+func TestQueryApplicationsPages(t *testing.T) {
+	d, u := newTestDB(t)
+	// Dates repeat, so pages must break ties on the ID
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 7; i++ {
+		_, err := d.CreateApplication(u.ID, models.CreateApplicationRequest{
+			Company: fmt.Sprintf("Company %d", i), Role: "Engineer", Status: "Applied", Date: day.AddDate(0, 0, i/2),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	all, _, err := d.QueryApplications(u.ID, models.ApplicationFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, base := range []models.ListOptions{{}, {Order: "asc"}, {Sort: "company"}} {
+		want, _, err := d.QueryApplications(u.ID, models.ApplicationFilter{ListOptions: base})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []models.Application
+		opts := base
+		opts.Limit = 3
+		for pages := 0; ; pages++ {
+			if pages > len(all) {
+				t.Fatalf("%+v: paging does not end", base)
+			}
+			page, next, err := d.QueryApplications(u.ID, models.ApplicationFilter{ListOptions: opts})
+			if err != nil {
+				t.Fatalf("%+v: %v", base, err)
+			}
+			if len(page) > opts.Limit {
+				t.Fatalf("%+v: page of %d, want at most %d", base, len(page), opts.Limit)
+			}
+			got = append(got, page...)
+			if next == "" {
+				break
+			}
+			opts.Cursor = next
+		}
+		if ids(got) == nil || !reflect.DeepEqual(ids(got), ids(want)) {
+			t.Errorf("%+v: pages hold %v, want %v", base, ids(got), ids(want))
+		}
+	}
+}
+
+func ids(apps []models.Application) []string {
+	var out []string
+	for _, a := range apps {
+		out = append(out, a.ID)
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"time"
+)
+
+// LastActivity returns, per application the user owns, the time of its
+// most recent timeline event.
+func (db *DB) LastActivity(userID string) (map[string]time.Time, error) {
+	rows, err := db.conn.Query(
+		`SELECT e.application_id, e.occurred_at
+		FROM application_events e
+		JOIN applications a ON a.id = e.application_id
+		WHERE a.user_id = ?`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	last := map[string]time.Time{}
+	for rows.Next() {
+		var appID, occurredAt string
+		if err := rows.Scan(&appID, &occurredAt); err != nil {
+			return nil, err
+		}
+		t, _ := parseTime(occurredAt)
+		if t.After(last[appID]) {
+			last[appID] = t
+		}
+	}
+	return last, rows.Err()
+}
+
+// ClaimNotification records that the notification identified by key is
+// being sent. It returns false if it was already claimed, so each
+// notification goes out once even across restarts.
+func (db *DB) ClaimNotification(key string) (bool, error) {
+	res, err := db.conn.Exec(
+		`INSERT OR IGNORE INTO notifications_sent (key, sent_at) VALUES (?, ?)`,
+		key, time.Now().UTC(),
+	)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// ReleaseNotification forgets a claim, so a notification that failed to
+// send is retried.
+func (db *DB) ReleaseNotification(key string) error {
+	_, err := db.conn.Exec(`DELETE FROM notifications_sent WHERE key = ?`, key)
+	return err
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+func TestOwnApplicationsOnly(t *testing.T) {
+	d, u := newTestDB(t)
+	own, err := d.CreateApplication(u.ID, models.CreateApplicationRequest{Company: "Acme", Role: "Engineer", Status: "Applied", Date: time.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy, err := d.CreateApplication(u.ID, models.CreateApplicationRequest{Company: "Initech", Role: "Analyst", Status: "Applied", Date: time.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Applications from before accounts existed have no owner
+	if _, err := d.conn.Exec(`UPDATE applications SET user_id = NULL WHERE id = ?`, legacy.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	all, _, err := d.QueryApplications(u.ID, models.ApplicationFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 {
+		t.Errorf("QueryApplications() returned %d applications, want both", len(all))
+	}
+	owned, _, err := d.QueryApplications(u.ID, models.ApplicationFilter{OwnOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(owned) != 1 || owned[0].ID != own.ID {
+		t.Errorf("QueryApplications(OwnOnly) = %v, want only %s", ids(owned), own.ID)
+	}
+
+	last, err := d.LastActivity(u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := last[legacy.ID]; ok || last[own.ID].IsZero() {
+		t.Errorf("LastActivity() = %v, want only %s", last, own.ID)
+	}
+}
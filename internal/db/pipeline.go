@@ -0,0 +1,173 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrStageInUse is returned when removing a pipeline stage that
+// applications are still in.
+var ErrStageInUse = errors.New("stage in use")
+
+// GetPipeline returns the user's pipeline stages in order, seeding the
+// default pipeline on first use.
+func (db *DB) GetPipeline(userID string) ([]models.PipelineStage, error) {
+	stages, err := db.listStages(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(stages) > 0 {
+		return stages, nil
+	}
+
+	for i, s := range models.DefaultPipeline() {
+		_, err := db.conn.Exec(
+			`INSERT OR IGNORE INTO pipeline_stages (id, user_id, name, position, terminal, color, category) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			uuid.New().String(), userID, s.Name, i, s.Terminal, s.Color, s.Category,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return db.listStages(userID)
+}
+
+// ReplacePipeline stores stages as the user's pipeline. Stages with a known
+// ID are updated, and renaming one moves its applications along. Stages
+// left out are deleted, unless applications are still in them.
+func (db *DB) ReplacePipeline(userID string, stages []models.PipelineStage) ([]models.PipelineStage, error) {
+	current, err := db.GetPipeline(userID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]models.PipelineStage, len(current))
+	for _, s := range current {
+		byID[s.ID] = s
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	kept := map[string]bool{}
+	for _, s := range stages {
+		if _, exists := byID[s.ID]; exists {
+			kept[s.ID] = true
+		}
+	}
+	for _, old := range current {
+		if kept[old.ID] {
+			continue
+		}
+		var n int
+		err := tx.QueryRow(
+			`SELECT COUNT(*) FROM applications WHERE status = ? AND (user_id = ? OR user_id IS NULL)`,
+			old.Name, userID,
+		).Scan(&n)
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			return nil, fmt.Errorf("%w: %d applications are in %q", ErrStageInUse, n, old.Name)
+		}
+		if _, err := tx.Exec(`DELETE FROM pipeline_stages WHERE id = ? AND user_id = ?`, old.ID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Park renamed stages and their applications under placeholder names
+	// first, so that swapping or chaining names cannot collide
+	for _, s := range stages {
+		old, exists := byID[s.ID]
+		if !exists || old.Name == s.Name {
+			continue
+		}
+		if _, err := tx.Exec(`UPDATE pipeline_stages SET name = ? WHERE id = ?`, "~"+s.ID, s.ID); err != nil {
+			return nil, err
+		}
+		if err := renameStatus(tx, userID, old.Name, "~"+s.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, s := range stages {
+		old, exists := byID[s.ID]
+		if !exists {
+			_, err := tx.Exec(
+				`INSERT INTO pipeline_stages (id, user_id, name, position, terminal, color, category) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+				uuid.New().String(), userID, s.Name, i, s.Terminal, s.Color, s.Category,
+			)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		_, err := tx.Exec(
+			`UPDATE pipeline_stages SET name = ?, position = ?, terminal = ?, color = ?, category = ? WHERE id = ? AND user_id = ?`,
+			s.Name, i, s.Terminal, s.Color, s.Category, s.ID, userID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if old.Name != s.Name {
+			if err := renameStatus(tx, userID, "~"+s.ID, s.Name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return db.listStages(userID)
+}
+
+// renameStatus moves the user's applications and their recorded status
+// changes from one status name to another.
+func renameStatus(tx *sql.Tx, userID, from, to string) error {
+	owned := `(SELECT id FROM applications WHERE user_id = ? OR user_id IS NULL)`
+	if _, err := tx.Exec(
+		`UPDATE application_events SET to_value = ? WHERE to_value = ? AND type IN (?, ?) AND application_id IN `+owned,
+		to, from, models.EventCreated, models.EventStatusChange, userID,
+	); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(
+		`UPDATE application_events SET from_value = ? WHERE from_value = ? AND type = ? AND application_id IN `+owned,
+		to, from, models.EventStatusChange, userID,
+	); err != nil {
+		return err
+	}
+	_, err := tx.Exec(
+		`UPDATE applications SET status = ? WHERE status = ? AND (user_id = ? OR user_id IS NULL)`,
+		to, from, userID,
+	)
+	return err
+}
+
+func (db *DB) listStages(userID string) ([]models.PipelineStage, error) {
+	rows, err := db.conn.Query(
+		`SELECT id, name, position, terminal, color, category FROM pipeline_stages WHERE user_id = ? ORDER BY position`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stages []models.PipelineStage
+	for rows.Next() {
+		var s models.PipelineStage
+		if err := rows.Scan(&s.ID, &s.Name, &s.Position, &s.Terminal, &s.Color, &s.Category); err != nil {
+			return nil, err
+		}
+		stages = append(stages, s)
+	}
+	return stages, rows.Err()
+}
@@ -0,0 +1,76 @@
+// Package document renders simple flowing documents, such as cover letters,
+// to PDF and DOCX without external dependencies. Layout is deliberately
+// plain: one column of headings, paragraphs and tables on A4 pages.
+package document
+
+import "strings"
+
+// Document is a title and a sequence of blocks. The title is stored as
+// metadata only; add a Heading block to show it.
+type Document struct {
+	Title  string
+	Blocks []Block
+}
+
+// BlockKind is the kind of a block.
+type BlockKind int
+
+const (
+	Paragraph BlockKind = iota
+	Heading
+	Table
+)
+
+// Block is a heading, paragraph or table. Line breaks within Text and
+// table cells are kept.
+type Block struct {
+	Kind   BlockKind
+	Text   string
+	Rows   [][]string // Table cells; the first row is the header, repeated on each page
+	Widths []float64  // Relative widths of the table columns; equal when nil
+}
+
+// columnWidths splits total between the columns of a table in proportion
+// to its widths.
+func (b Block) columnWidths(total float64) []float64 {
+	n := 0
+	for _, row := range b.Rows {
+		n = max(n, len(row))
+	}
+	widths := make([]float64, n)
+	sum := 0.0
+	for i := range widths {
+		widths[i] = 1
+		if i < len(b.Widths) && b.Widths[i] > 0 {
+			widths[i] = b.Widths[i]
+		}
+		sum += widths[i]
+	}
+	for i := range widths {
+		widths[i] *= total / sum
+	}
+	return widths
+}
+
+// Paragraphs splits text into paragraphs on blank lines, keeping the line
+// breaks within each paragraph.
+func Paragraphs(text string) []Block {
+	var blocks []Block
+	var lines []string
+	flush := func() {
+		if len(lines) > 0 {
+			blocks = append(blocks, Block{Kind: Paragraph, Text: strings.Join(lines, "\n")})
+			lines = nil
+		}
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			flush()
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return blocks
+}
@@ -0,0 +1,125 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>` +
+	`<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>` +
+	`</Types>`
+
+const docxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>` +
+	`</Relationships>`
+
+// WriteDOCX renders doc as a Word document.
+func WriteDOCX(w io.Writer, doc Document) error {
+	var body strings.Builder
+	body.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`)
+	for _, b := range doc.Blocks {
+		switch b.Kind {
+		case Heading:
+			docxParagraph(&body, `<w:keepNext/><w:spacing w:before="240" w:after="120"/>`,
+				`<w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri"/><w:b/><w:sz w:val="28"/></w:rPr>`, b.Text)
+		case Table:
+			docxTable(&body, b)
+		default:
+			docxParagraph(&body, `<w:spacing w:after="180"/>`,
+				`<w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri"/><w:sz w:val="22"/></w:rPr>`, b.Text)
+		}
+	}
+	// A4 with 2.25cm margins, in twentieths of a point
+	body.WriteString(`<w:sectPr><w:pgSz w:w="11906" w:h="16838"/>` +
+		`<w:pgMar w:top="1276" w:right="1276" w:bottom="1276" w:left="1276" w:header="708" w:footer="708" w:gutter="0"/>` +
+		`</w:sectPr></w:body></w:document>`)
+
+	core := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/">` +
+		`<dc:title>` + xmlText(doc.Title) + `</dc:title></cp:coreProperties>`
+
+	zw := zip.NewWriter(w)
+	for _, part := range []struct{ name, content string }{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxRels},
+		{"docProps/core.xml", core},
+		{"word/document.xml", body.String()},
+	} {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// docxParagraph writes a paragraph of a single run, with line breaks.
+func docxParagraph(body *strings.Builder, pPr, rPr, text string) {
+	body.WriteString(`<w:p><w:pPr>` + pPr + `</w:pPr><w:r>` + rPr)
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			body.WriteString(`<w:br/>`)
+		}
+		body.WriteString(`<w:t xml:space="preserve">` + xmlText(line) + `</w:t>`)
+	}
+	body.WriteString(`</w:r></w:p>`)
+}
+
+// docxTable writes a table with light horizontal rules and a bold header
+// row that repeats on each page. Widths are in twentieths of a point,
+// across the width between the margins.
+func docxTable(body *strings.Builder, b Block) {
+	if len(b.Rows) == 0 {
+		return
+	}
+	widths := b.columnWidths(11906 - 2*1276)
+	body.WriteString(`<w:tbl><w:tblPr><w:tblW w:w="0" w:type="auto"/><w:tblLayout w:type="fixed"/>` +
+		`<w:tblBorders><w:top w:val="single" w:sz="6" w:color="333333"/><w:bottom w:val="single" w:sz="4" w:color="BFBFBF"/>` +
+		`<w:insideH w:val="single" w:sz="4" w:color="BFBFBF"/></w:tblBorders>` +
+		`<w:tblCellMar><w:left w:w="60" w:type="dxa"/><w:right w:w="60" w:type="dxa"/></w:tblCellMar></w:tblPr><w:tblGrid>`)
+	for _, w := range widths {
+		fmt.Fprintf(body, `<w:gridCol w:w="%d"/>`, int(w))
+	}
+	body.WriteString(`</w:tblGrid>`)
+	for i, row := range b.Rows {
+		rPr := `<w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri"/><w:sz w:val="18"/></w:rPr>`
+		body.WriteString(`<w:tr><w:trPr><w:cantSplit/>`)
+		if i == 0 {
+			rPr = `<w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri"/><w:b/><w:sz w:val="18"/></w:rPr>`
+			body.WriteString(`<w:tblHeader/>`)
+		}
+		body.WriteString(`</w:trPr>`)
+		for j, w := range widths {
+			cell := ""
+			if j < len(row) {
+				cell = row[j]
+			}
+			fmt.Fprintf(body, `<w:tc><w:tcPr><w:tcW w:w="%d" w:type="dxa"/></w:tcPr>`, int(w))
+			docxParagraph(body, `<w:spacing w:before="40" w:after="40"/>`, rPr, cell)
+			body.WriteString(`</w:tc>`)
+		}
+		body.WriteString(`</w:tr>`)
+	}
+	// Word wants a paragraph between a table and what follows
+	body.WriteString(`</w:tbl><w:p/>`)
+}
+
+func xmlText(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,330 @@
+package document
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Page geometry in points (A4).
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+	margin     = 64.0
+)
+
+type pdfFont struct {
+	name   string // Resource name in the page
+	widths *[95]int
+	size   float64
+	after  float64 // Space after a block
+}
+
+var (
+	bodyFont      = pdfFont{name: "F1", widths: &helveticaWidths, size: 11, after: 9}
+	headingFont   = pdfFont{name: "F2", widths: &helveticaBoldWidths, size: 14, after: 6}
+	cellFont      = pdfFont{name: "F1", widths: &helveticaWidths, size: 9}
+	headerRowFont = pdfFont{name: "F2", widths: &helveticaBoldWidths, size: 9}
+)
+
+// cellPadding is the space around the text of a table cell.
+const cellPadding = 3.0
+
+func (f pdfFont) lineHeight() float64 { return f.size * 1.35 }
+
+// width returns the width of WinAnsi-encoded s in points.
+func (f pdfFont) width(s []byte) float64 {
+	w := 0
+	for _, c := range s {
+		if c >= 32 && c <= 126 {
+			w += f.widths[c-32]
+		} else {
+			w += 556
+		}
+	}
+	return float64(w) * f.size / 1000
+}
+
+// ErrUnsupportedText is returned for documents with characters the PDF
+// fonts cannot show.
+var ErrUnsupportedText = errors.New("text has characters a PDF cannot show")
+
+// maxReportedChars caps the characters named in an ErrUnsupportedText error.
+const maxReportedChars = 10
+
+// WritePDF renders doc as a PDF using the standard Helvetica fonts, which
+// cover Windows-1252: English and most Western European languages. Rather
+// than print other characters wrong, such as Polish, Greek or Chinese
+// letters, it fails with ErrUnsupportedText.
+func WritePDF(w io.Writer, doc Document) error {
+	if chars := UnsupportedPDFChars(doc); len(chars) > 0 {
+		if len(chars) > maxReportedChars {
+			chars = chars[:maxReportedChars]
+		}
+		quoted := make([]string, len(chars))
+		for i, r := range chars {
+			quoted[i] = string(r)
+		}
+		return fmt.Errorf("%w: %s", ErrUnsupportedText, strings.Join(quoted, " "))
+	}
+
+	var pages []*bytes.Buffer
+	var page *bytes.Buffer
+	y := 0.0
+	newPage := func() {
+		page = &bytes.Buffer{}
+		pages = append(pages, page)
+		y = pageHeight - margin
+	}
+	newPage()
+
+	for i, b := range doc.Blocks {
+		if b.Kind == Table {
+			y = writeTable(b, &page, y, newPage)
+			y -= bodyFont.after
+			continue
+		}
+		f := bodyFont
+		if b.Kind == Heading {
+			f = headingFont
+			// Keep some room so a heading is not left alone at the foot
+			if i > 0 {
+				y -= f.size * 0.6
+			}
+			if y-3*bodyFont.lineHeight() < margin {
+				newPage()
+			}
+		}
+		for _, line := range wrapText(f, winAnsi(b.Text), pageWidth-2*margin) {
+			if y-f.lineHeight() < margin {
+				newPage()
+			}
+			y -= f.lineHeight()
+			fmt.Fprintf(page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", f.name, f.size, margin, y+f.size*0.25, pdfEscape(line))
+		}
+		y -= f.after
+	}
+
+	// Objects: 1 catalog, 2 page tree, 3-4 fonts, 5 info, then a page and
+	// its content stream for each page
+	var out bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	obj(fmt.Sprintf("<< /Title (%s) /Producer (CV Forge) >>", pdfEscape(winAnsi(doc.Title))))
+	for i, p := range pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 7+2*i))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.Len(), p.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// writeTable draws a table from y down, starting new pages as needed, and
+// returns where it ends. A row is kept on one page.
+func writeTable(b Block, page **bytes.Buffer, y float64, newPage func()) float64 {
+	if len(b.Rows) == 0 {
+		return y
+	}
+	widths := b.columnWidths(pageWidth - 2*margin)
+	top := pageHeight - margin
+
+	var drawRow func(row []string, f pdfFont, header bool)
+	drawRow = func(row []string, f pdfFont, header bool) {
+		cells := make([][][]byte, len(widths))
+		lines := 1
+		for j := range widths {
+			if j < len(row) {
+				cells[j] = wrapText(f, winAnsi(row[j]), widths[j]-2*cellPadding)
+			}
+			lines = max(lines, len(cells[j]))
+		}
+		height := float64(lines)*f.lineHeight() + 2*cellPadding
+		if y-height < margin && y < top {
+			newPage()
+			y = top
+			if !header {
+				drawRow(b.Rows[0], headerRowFont, true)
+			}
+		}
+
+		x := margin
+		for j, cell := range cells {
+			for k, line := range cell {
+				if len(line) == 0 {
+					continue
+				}
+				baseline := y - cellPadding - float64(k+1)*f.lineHeight() + f.size*0.25
+				fmt.Fprintf(*page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", f.name, f.size, x+cellPadding, baseline, pdfEscape(line))
+			}
+			x += widths[j]
+		}
+		y -= height
+		gray, weight := 0.75, 0.5
+		if header {
+			gray, weight = 0.2, 0.8
+		}
+		fmt.Fprintf(*page, "%.2f G %.1f w %.2f %.2f m %.2f %.2f l S\n", gray, weight, margin, y, pageWidth-margin, y)
+	}
+
+	// Do not leave the header alone at the foot of a page
+	if y-2*(headerRowFont.lineHeight()+2*cellPadding) < margin {
+		newPage()
+		y = top
+	}
+	drawRow(b.Rows[0], headerRowFont, true)
+	for _, row := range b.Rows[1:] {
+		drawRow(row, cellFont, false)
+	}
+	return y
+}
+
+// wrapText breaks text into lines no wider than width, at spaces where
+// possible. Line breaks in text are kept.
+func wrapText(f pdfFont, text []byte, width float64) [][]byte {
+	var lines [][]byte
+	for _, para := range bytes.Split(text, []byte("\n")) {
+		var line []byte
+		for _, word := range bytes.Fields(para) {
+			candidate := word
+			if len(line) > 0 {
+				candidate = append(append(append([]byte{}, line...), ' '), word...)
+			}
+			if f.width(candidate) <= width {
+				line = candidate
+				continue
+			}
+			if len(line) > 0 {
+				lines = append(lines, line)
+			}
+			// Break words that do not fit on a line of their own
+			for f.width(word) > width {
+				n := 1
+				for n < len(word) && f.width(word[:n+1]) <= width {
+					n++
+				}
+				lines = append(lines, word[:n])
+				word = word[n:]
+			}
+			line = word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// UnsupportedPDFChars returns the characters of doc that WritePDF cannot
+// show, each once, in order of appearance.
+func UnsupportedPDFChars(doc Document) []rune {
+	var chars []rune
+	seen := map[rune]bool{}
+	check := func(s string) {
+		for _, r := range s {
+			if _, ok := winAnsiByte(r); !ok && r >= 32 && !seen[r] {
+				seen[r] = true
+				chars = append(chars, r)
+			}
+		}
+	}
+	check(doc.Title)
+	for _, b := range doc.Blocks {
+		check(b.Text)
+		for _, row := range b.Rows {
+			for _, cell := range row {
+				check(cell)
+			}
+		}
+	}
+	return chars
+}
+
+// winAnsi encodes s in Windows-1252, the encoding of the standard fonts.
+// Other control characters are dropped and other characters, which
+// WritePDF refuses beforehand, replaced with '?'.
+func winAnsi(s string) []byte {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch c, ok := winAnsiByte(r); {
+		case r == '\t':
+			b = append(b, ' ')
+		case ok:
+			b = append(b, c)
+		case r >= 32:
+			b = append(b, '?')
+		}
+	}
+	return b
+}
+
+// winAnsiByte returns the Windows-1252 code of a printable character or a
+// line break.
+func winAnsiByte(r rune) (byte, bool) {
+	if r == '\n' || (r >= 32 && r <= 126) || (r >= 160 && r <= 255) {
+		return byte(r), true
+	}
+	c, ok := winAnsiExtra[r]
+	return c, ok
+}
+
+var winAnsiExtra = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b,
+	'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+func pdfEscape(s []byte) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch c {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// Glyph widths of the printable ASCII range, from the Adobe font metrics.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
@@ -0,0 +1,49 @@
+package document
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWritePDF(t *testing.T) {
+	doc := Document{Title: "Lettre – Café", Blocks: append(Paragraphs("Grüße aus Köln\n\n“Quoted” € 50\tnet"),
+		Block{Kind: Table, Rows: [][]string{{"Company", "Role"}, {"Société Générale", "Développeur"}}})}
+	var buf bytes.Buffer
+	if err := WritePDF(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "%PDF-1.4") || !strings.HasSuffix(out, "%%EOF\n") {
+		t.Errorf("WritePDF() wrote %d bytes that do not look like a PDF", buf.Len())
+	}
+	// Windows-1252 text is written as such, not replaced
+	for _, want := range []string{"(Gr\xfc\xdfe aus K\xf6ln)", "(\x93Quoted\x94 \x80 50 net)", "(Soci\xe9t\xe9 G\xe9n\xe9rale)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WritePDF() output lacks %q", want)
+		}
+	}
+}
+
+func TestWritePDFUnsupportedText(t *testing.T) {
+	doc := Document{Title: "Łódź", Blocks: []Block{
+		{Kind: Paragraph, Text: "Dear Ms Dvořáková, Łukasz"},
+		{Kind: Table, Rows: [][]string{{"Company"}, {"株式会社"}, {"Ωmega"}}},
+	}}
+	// ó and á are in Windows-1252
+	want := []rune{'Ł', 'ź', 'ř', '株', '式', '会', '社', 'Ω'}
+	if got := UnsupportedPDFChars(doc); !reflect.DeepEqual(got, want) {
+		t.Errorf("UnsupportedPDFChars() = %q, want %q", got, want)
+	}
+
+	var buf bytes.Buffer
+	err := WritePDF(&buf, doc)
+	if !errors.Is(err, ErrUnsupportedText) {
+		t.Fatalf("WritePDF() error = %v, want %v", err, ErrUnsupportedText)
+	}
+	if !strings.Contains(err.Error(), "Ł ź ř") || buf.Len() != 0 {
+		t.Errorf("WritePDF() = %q with %d bytes written, want the characters named and nothing written", err, buf.Len())
+	}
+}
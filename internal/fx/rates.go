@@ -0,0 +1,94 @@
+// Package fx converts amounts between currencies using a locally configured
+// table of exchange rates. No rates are fetched from the network.
+package fx
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Rates maps ISO 4217 currency codes to how many units of that currency one
+// unit of a common base buys. The base has a rate of 1, e.g.
+//
+//	EUR=1,USD=1.08,GBP=0.86
+type Rates map[string]float64
+
+// ParseRates parses a comma-separated list of CODE=rate pairs.
+func ParseRates(s string) (Rates, error) {
+	rates := Rates{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		code, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("exchange rate %q: want CODE=rate", pair)
+		}
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if !ValidCode(code) {
+			return nil, fmt.Errorf("exchange rate %q: invalid currency code", pair)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("exchange rate %q: rate must be a positive number", pair)
+		}
+		rates[code] = rate
+	}
+	return rates, nil
+}
+
+// RatesFromEnv reads the EXCHANGE_RATES environment variable. It returns an
+// empty table if the variable is unset.
+func RatesFromEnv() (Rates, error) {
+	return ParseRates(os.Getenv("EXCHANGE_RATES"))
+}
+
+// ValidCode reports whether code looks like an ISO 4217 code.
+func ValidCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Base returns the currency with a rate of 1, or "" if there is none.
+func (r Rates) Base() string {
+	var codes []string
+	for code, rate := range r {
+		if rate == 1 {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return ""
+	}
+	sort.Strings(codes)
+	return codes[0]
+}
+
+// Convert converts amount from one currency to another. It reports false if
+// either currency is missing from the table; converting a currency to itself
+// always succeeds.
+func (r Rates) Convert(amount float64, from, to string) (float64, bool) {
+	if from == to {
+		return amount, true
+	}
+	fromRate, ok := r[from]
+	if !ok {
+		return 0, false
+	}
+	toRate, ok := r[to]
+	if !ok {
+		return 0, false
+	}
+	return amount / fromRate * toRate, true
+}
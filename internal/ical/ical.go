@@ -0,0 +1,118 @@
+// Package ical writes iCalendar (RFC 5545) files.
+package ical
+
+import (
+	"io"
+	"strings"
+	"time"
+)
+
+// Event is a single VEVENT. Times are written in UTC.
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool // Only the date of Start is used
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Updated     time.Time // DTSTAMP; defaults to now
+	Cancelled   bool
+}
+
+// Calendar is a VCALENDAR holding events.
+type Calendar struct {
+	Name   string // Shown by clients subscribing to the calendar
+	Events []Event
+}
+
+const (
+	timeFormat = "20060102T150405Z"
+	dateFormat = "20060102"
+)
+
+// Write encodes c to w.
+func (c Calendar) Write(w io.Writer) error {
+	var b strings.Builder
+	line := func(name, value string) {
+		writeLine(&b, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//CV Forge//CV Forge//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escape(c.Name))
+	}
+	for _, e := range c.Events {
+		stamp := e.Updated
+		if stamp.IsZero() {
+			stamp = time.Now()
+		}
+		line("BEGIN", "VEVENT")
+		line("UID", e.UID)
+		line("DTSTAMP", stamp.UTC().Format(timeFormat))
+		if e.AllDay {
+			line("DTSTART;VALUE=DATE", e.Start.Format(dateFormat))
+			line("DTEND;VALUE=DATE", e.Start.AddDate(0, 0, 1).Format(dateFormat))
+		} else {
+			line("DTSTART", e.Start.UTC().Format(timeFormat))
+			line("DTEND", e.End.UTC().Format(timeFormat))
+		}
+		line("SUMMARY", escape(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", escape(e.Description))
+		}
+		if e.Location != "" {
+			line("LOCATION", escape(e.Location))
+		}
+		if e.URL != "" {
+			line("URL", e.URL)
+		}
+		if e.Cancelled {
+			line("STATUS", "CANCELLED")
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeLine writes a content line, folded at 75 octets without splitting
+// UTF-8 sequences.
+func writeLine(b *strings.Builder, s string) {
+	const limit = 75
+	width := limit
+	for len(s) > width {
+		cut := width
+		for cut > 0 && !isRuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		width = limit - 1 // Continuation lines start with a space
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
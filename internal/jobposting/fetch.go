@@ -0,0 +1,116 @@
+package jobposting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// maxPageSize caps how much of a page is read.
+const maxPageSize = 5 << 20
+
+var (
+	ErrInvalidURL  = errors.New("url must be an absolute http or https address")
+	ErrBlockedHost = errors.New("url points to a private or local address")
+	ErrNotHTML     = errors.New("url does not point to an HTML page")
+)
+
+// Fetcher downloads and parses job posting pages.
+type Fetcher struct {
+	Client *http.Client
+}
+
+// NewFetcher returns a fetcher with a timeout. Unless allowPrivate is set,
+// it refuses to connect to loopback, private and link-local addresses, so
+// the server cannot be used to probe the network it runs in.
+func NewFetcher(allowPrivate bool) *Fetcher {
+	if allowPrivate {
+		return newFetcher(nil)
+	}
+	return newFetcher(publicIP)
+}
+
+// newFetcher returns a fetcher that only connects to the addresses allowed
+// accepts, or to any if it is nil. The check is made on every connection,
+// redirects included, after the host name is resolved.
+func newFetcher(allowed func(net.IP) bool) *Fetcher {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if allowed != nil {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !allowed(ip) {
+				return ErrBlockedHost
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &Fetcher{Client: &http.Client{Transport: transport, Timeout: 20 * time.Second}}
+}
+
+// NewFetcherFromEnv returns a fetcher configured from the environment.
+// IMPORT_ALLOW_PRIVATE_HOSTS=true allows fetching from local addresses,
+// e.g. to test against a stand-in server.
+func NewFetcherFromEnv() *Fetcher {
+	allow, _ := strconv.ParseBool(os.Getenv("IMPORT_ALLOW_PRIVATE_HOSTS"))
+	return NewFetcher(allow)
+}
+
+func publicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast() || ip.IsInterfaceLocalMulticast())
+}
+
+// Fetch downloads the page at rawURL and parses the posting on it.
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*Posting, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrInvalidURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, ErrInvalidURL
+	}
+	req.Header.Set("User-Agent", "CV Forge job posting import")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrBlockedHost) {
+			return nil, ErrBlockedHost
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("page returned %s", resp.Status)
+	}
+	if mt, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mt != "" && mt != "text/html" && mt != "application/xhtml+xml" {
+		return nil, ErrNotHTML
+	}
+	p, err := Parse(io.LimitReader(resp.Body, maxPageSize), resp.Request.URL.String())
+	if err != nil {
+		return nil, err
+	}
+	// Keep the address the user gave when the page names no canonical one
+	if p.URL == resp.Request.URL.String() {
+		p.URL = rawURL
+	}
+	return p, nil
+}
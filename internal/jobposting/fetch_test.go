@@ -0,0 +1,136 @@
+package jobposting
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// postingServer serves a job posting page at /job and the given handlers
+// at their paths.
+func postingServer(t *testing.T, handlers map[string]http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/job", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<html><head>
+			<script type="application/ld+json">{"@type": "JobPosting", "title": "Backend Engineer", "hiringOrganization": "Acme"}</script>
+			</head></html>`))
+	})
+	for path, h := range handlers {
+		mux.HandleFunc(path, h)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetch(t *testing.T) {
+	srv := postingServer(t, map[string]http.HandlerFunc{
+		"/moved": func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/job", http.StatusFound)
+		},
+	})
+	f := NewFetcher(true)
+
+	for _, path := range []string{"/job", "/moved"} {
+		p, err := f.Fetch(context.Background(), srv.URL+path)
+		if err != nil {
+			t.Fatalf("Fetch(%s): %v", path, err)
+		}
+		if p.Role != "Backend Engineer" || p.Company != "Acme" {
+			t.Errorf("Fetch(%s) = {Role:%q Company:%q}", path, p.Role, p.Company)
+		}
+		// Without a canonical URL on the page, the address asked for is kept
+		if p.URL != srv.URL+path {
+			t.Errorf("Fetch(%s).URL = %q", path, p.URL)
+		}
+	}
+}
+
+func TestFetchErrors(t *testing.T) {
+	srv := postingServer(t, map[string]http.HandlerFunc{
+		"/pdf": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF-1.4"))
+		},
+		"/gone": func(w http.ResponseWriter, r *http.Request) {
+			http.NotFound(w, r)
+		},
+	})
+	f := NewFetcher(true)
+
+	tests := []struct {
+		url  string
+		want error
+	}{
+		{srv.URL + "/pdf", ErrNotHTML},
+		{"ftp://example.com/job", ErrInvalidURL},
+		{"/job", ErrInvalidURL},
+		{"https://", ErrInvalidURL},
+	}
+	for _, tt := range tests {
+		if _, err := f.Fetch(context.Background(), tt.url); !errors.Is(err, tt.want) {
+			t.Errorf("Fetch(%q) error = %v, want %v", tt.url, err, tt.want)
+		}
+	}
+	if _, err := f.Fetch(context.Background(), srv.URL+"/gone"); err == nil {
+		t.Error("Fetch of a missing page succeeded")
+	}
+}
+
+func TestFetchBlocksPrivateHosts(t *testing.T) {
+	srv := postingServer(t, nil)
+	if _, err := NewFetcher(false).Fetch(context.Background(), srv.URL+"/job"); !errors.Is(err, ErrBlockedHost) {
+		t.Errorf("Fetch of a loopback address error = %v, want %v", err, ErrBlockedHost)
+	}
+}
+
+func TestFetchBlocksRedirectToPrivateHost(t *testing.T) {
+	// The private server listens on a second loopback address; the fetcher
+	// treats 127.0.0.1 as public, standing in for a page on the internet
+	// that redirects into the local network.
+	ln, err := net.Listen("tcp", "127.0.0.2:0")
+	if err != nil {
+		t.Skipf("no second loopback address: %v", err)
+	}
+	private := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("private server was reached")
+	}))
+	private.Listener.Close()
+	private.Listener = ln
+	private.Start()
+	t.Cleanup(private.Close)
+
+	public := postingServer(t, map[string]http.HandlerFunc{
+		"/redirect": func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, private.URL+"/admin", http.StatusFound)
+		},
+	})
+	f := newFetcher(func(ip net.IP) bool {
+		return ip.Equal(net.IPv4(127, 0, 0, 1)) || publicIP(ip)
+	})
+
+	if _, err := f.Fetch(context.Background(), public.URL+"/job"); err != nil {
+		t.Fatalf("Fetch from the allowed server: %v", err)
+	}
+	if _, err := f.Fetch(context.Background(), public.URL+"/redirect"); !errors.Is(err, ErrBlockedHost) {
+		t.Errorf("Fetch redirected to a private address error = %v, want %v", err, ErrBlockedHost)
+	}
+}
+
+func TestNewFetcherFromEnv(t *testing.T) {
+	srv := postingServer(t, nil)
+
+	t.Setenv("IMPORT_ALLOW_PRIVATE_HOSTS", "true")
+	if _, err := NewFetcherFromEnv().Fetch(context.Background(), srv.URL+"/job"); err != nil {
+		t.Errorf("with IMPORT_ALLOW_PRIVATE_HOSTS=true: %v", err)
+	}
+	t.Setenv("IMPORT_ALLOW_PRIVATE_HOSTS", "")
+	if _, err := NewFetcherFromEnv().Fetch(context.Background(), srv.URL+"/job"); !errors.Is(err, ErrBlockedHost) {
+		t.Errorf("without IMPORT_ALLOW_PRIVATE_HOSTS error = %v, want %v", err, ErrBlockedHost)
+	}
+}
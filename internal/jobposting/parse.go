@@ -0,0 +1,392 @@
+// Package jobposting reads job postings from web pages. It understands
+// schema.org JobPosting JSON-LD, which most job boards embed for search
+// engines, and falls back to OpenGraph and plain meta tags.
+package jobposting
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Posting is what could be read from a job posting page. Fields the page
+// does not provide are left empty.
+type Posting struct {
+	URL          string               `json:"url"` // Canonical URL if the page names one
+	Role         string               `json:"role"`
+	Company      string               `json:"company"`
+	Location     string               `json:"location"`
+	Description  string               `json:"description"` // Plain text
+	Compensation *models.Compensation `json:"compensation"`
+	DatePosted   *time.Time           `json:"datePosted"`
+	ValidThrough *time.Time           `json:"validThrough"` // Closing date
+}
+
+// Text renders the posting as plain text, for keeping a copy.
+func (p *Posting) Text() string {
+	var b strings.Builder
+	for _, line := range []struct{ label, value string }{
+		{"Role", p.Role},
+		{"Company", p.Company},
+		{"Location", p.Location},
+		{"URL", p.URL},
+	} {
+		if line.value != "" {
+			b.WriteString(line.label + ": " + line.value + "\n")
+		}
+	}
+	if p.Compensation != nil {
+		b.WriteString("Salary: " + p.Compensation.String() + "\n")
+	}
+	if p.Description != "" {
+		b.WriteString("\n" + p.Description + "\n")
+	}
+	return b.String()
+}
+
+// Parse reads a posting from an HTML page fetched from pageURL.
+func Parse(r io.Reader, pageURL string) (*Posting, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var jsonLD []string
+	meta := map[string]string{}
+	var title, canonical string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.DataAtom {
+			case atom.Script:
+				if strings.EqualFold(strings.TrimSpace(attr(n, "type")), "application/ld+json") {
+					jsonLD = append(jsonLD, textContent(n))
+				}
+			case atom.Meta:
+				key := strings.ToLower(attr(n, "property"))
+				if key == "" {
+					key = strings.ToLower(attr(n, "name"))
+				}
+				if _, seen := meta[key]; key != "" && !seen {
+					meta[key] = strings.TrimSpace(attr(n, "content"))
+				}
+			case atom.Title:
+				if title == "" {
+					title = strings.TrimSpace(textContent(n))
+				}
+			case atom.Link:
+				if strings.EqualFold(attr(n, "rel"), "canonical") && canonical == "" {
+					canonical = attr(n, "href")
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	p := &Posting{}
+	for _, src := range jsonLD {
+		var v any
+		if json.Unmarshal([]byte(src), &v) != nil {
+			continue
+		}
+		if jp := findJobPosting(v); jp != nil {
+			p.fromJSONLD(jp)
+			break
+		}
+	}
+
+	// Fill the gaps from OpenGraph and meta tags
+	if p.Role == "" {
+		p.Role = firstNonEmpty(meta["og:title"], meta["twitter:title"], title)
+	}
+	if p.Company == "" {
+		p.Company = meta["og:site_name"]
+	}
+	if p.Description == "" {
+		p.Description = firstNonEmpty(meta["og:description"], meta["description"], meta["twitter:description"])
+	}
+	if p.URL == "" {
+		p.URL = firstNonEmpty(canonical, meta["og:url"])
+	}
+	p.URL = resolveURL(pageURL, p.URL)
+	return p, nil
+}
+
+// findJobPosting looks for a JobPosting object in decoded JSON-LD, which may
+// be a single object, an array or a @graph.
+func findJobPosting(v any) map[string]any {
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			if jp := findJobPosting(item); jp != nil {
+				return jp
+			}
+		}
+	case map[string]any:
+		if hasType(v, "JobPosting") {
+			return v
+		}
+		if graph, ok := v["@graph"]; ok {
+			return findJobPosting(graph)
+		}
+	}
+	return nil
+}
+
+func hasType(obj map[string]any, name string) bool {
+	switch t := obj["@type"].(type) {
+	case string:
+		return t == name
+	case []any:
+		for _, item := range t {
+			if s, _ := item.(string); s == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *Posting) fromJSONLD(jp map[string]any) {
+	p.Role = str(jp["title"])
+	p.URL = str(jp["url"])
+	if desc := str(jp["description"]); desc != "" {
+		p.Description = HTMLToText(desc)
+	}
+
+	switch org := jp["hiringOrganization"].(type) {
+	case string:
+		p.Company = org
+	case map[string]any:
+		p.Company = str(org["name"])
+	}
+
+	p.Location = jobLocation(jp["jobLocation"])
+	if strings.EqualFold(str(jp["jobLocationType"]), "TELECOMMUTE") {
+		if p.Location == "" {
+			p.Location = "Remote"
+		} else {
+			p.Location += " (remote)"
+		}
+	}
+
+	p.Compensation = baseSalary(jp["baseSalary"])
+	p.DatePosted = parseDate(str(jp["datePosted"]))
+	p.ValidThrough = parseDate(str(jp["validThrough"]))
+}
+
+// jobLocation formats a schema.org Place, or a list of them.
+func jobLocation(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []any:
+		var places []string
+		for _, item := range v {
+			if s := jobLocation(item); s != "" {
+				places = append(places, s)
+			}
+		}
+		return strings.Join(places, "; ")
+	case map[string]any:
+		switch addr := v["address"].(type) {
+		case string:
+			return addr
+		case map[string]any:
+			var parts []string
+			for _, key := range []string{"addressLocality", "addressRegion", "addressCountry"} {
+				part := str(addr[key])
+				if c, ok := addr[key].(map[string]any); ok {
+					part = str(c["name"])
+				}
+				if part != "" {
+					parts = append(parts, part)
+				}
+			}
+			return strings.Join(parts, ", ")
+		}
+		return str(v["name"])
+	}
+	return ""
+}
+
+// baseSalary reads a schema.org MonetaryAmount.
+func baseSalary(v any) *models.Compensation {
+	amount, ok := v.(map[string]any)
+	if !ok {
+		if s := str(v); s != "" {
+			return models.ParseSalary(s)
+		}
+		return nil
+	}
+	c := &models.Compensation{
+		Currency: strings.ToUpper(str(amount["currency"])),
+		Period:   models.PeriodYear,
+		Benefits: []string{},
+	}
+	unit := ""
+	switch value := amount["value"].(type) {
+	case float64:
+		c.Min, c.Max = value, value
+	case string:
+		return models.ParseSalary(value)
+	case map[string]any:
+		c.Min = num(value["minValue"])
+		c.Max = num(value["maxValue"])
+		if v := num(value["value"]); v != 0 && c.Min == 0 && c.Max == 0 {
+			c.Min, c.Max = v, v
+		}
+		unit = str(value["unitText"])
+	}
+	if unit == "" {
+		unit = str(amount["unitText"])
+	}
+	if period := models.PayPeriod(strings.ToLower(unit)); period.Valid() {
+		c.Period = period
+	}
+	if c.Max < c.Min {
+		c.Max = c.Min
+	}
+	if c.Min == 0 && c.Max == 0 {
+		return nil
+	}
+	return c
+}
+
+// parseDate reads an ISO 8601 date or date and time, in UTC.
+func parseDate(s string) *time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			t = t.UTC()
+			return &t
+		}
+	}
+	return nil
+}
+
+// HTMLToText turns an HTML fragment, as found in JSON-LD descriptions, into
+// plain text with a line per block.
+func HTMLToText(s string) string {
+	nodes, err := html.ParseFragment(strings.NewReader(s), &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div})
+	if err != nil {
+		return s
+	}
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			b.WriteString(n.Data)
+		case html.ElementNode:
+			switch n.DataAtom {
+			case atom.Script, atom.Style:
+				return
+			case atom.Br:
+				b.WriteString("\n")
+			case atom.Li:
+				b.WriteString("\n- ")
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if n.Type == html.ElementNode {
+			switch n.DataAtom {
+			case atom.P, atom.Div, atom.Ul, atom.Ol, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Tr:
+				b.WriteString("\n")
+			}
+		}
+	}
+	for _, n := range nodes {
+		walk(n)
+	}
+	return tidyText(b.String())
+}
+
+// tidyText collapses runs of spaces and keeps at most one blank line.
+func tidyText(s string) string {
+	var lines []string
+	blank := false
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			if len(lines) > 0 && !blank {
+				lines = append(lines, "")
+			}
+			blank = true
+			continue
+		}
+		lines = append(lines, line)
+		blank = false
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.TextNode {
+			b.WriteString(c.Data)
+		}
+	}
+	return b.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func str(v any) string {
+	s, _ := v.(string)
+	return strings.TrimSpace(html.UnescapeString(s))
+}
+
+func num(v any) float64 {
+	switch v := v.(type) {
+	case float64:
+		return v
+	case string:
+		if c := models.ParseSalary(v); c != nil {
+			return c.Min
+		}
+	}
+	return 0
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func resolveURL(base, ref string) string {
+	b, err := url.Parse(base)
+	if err != nil {
+		return ref
+	}
+	if ref == "" {
+		return b.String()
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return base
+	}
+	return b.ResolveReference(r).String()
+}
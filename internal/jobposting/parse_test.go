@@ -0,0 +1,160 @@
+package jobposting
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+const jsonLDPage = `<!doctype html>
+<html><head>
+<title>Backend Engineer | Jobs</title>
+<meta property="og:title" content="Not the role">
+<meta property="og:site_name" content="Jobs Board">
+<link rel="canonical" href="/jobs/42">
+<script type="application/ld+json">{"@context": "https://schema.org", "@type": "Organization", "name": "Jobs Board"}</script>
+<script type="application/ld+json">
+{
+  "@context": "https://schema.org",
+  "@graph": [
+    {"@type": "WebPage", "name": "Listing"},
+    {
+      "@type": ["JobPosting"],
+      "title": "Backend Engineer",
+      "description": "<p>Build &amp; run <b>APIs</b>.</p><ul><li>Go</li><li>SQL</li></ul>",
+      "hiringOrganization": {"@type": "Organization", "name": "Acme &amp; Sons"},
+      "jobLocation": [
+        {"@type": "Place", "address": {"addressLocality": "Berlin", "addressCountry": {"name": "DE"}}},
+        {"@type": "Place", "address": "Munich, DE"}
+      ],
+      "jobLocationType": "TELECOMMUTE",
+      "baseSalary": {"@type": "MonetaryAmount", "currency": "eur", "value": {"minValue": 60000, "maxValue": "70,000", "unitText": "YEAR"}},
+      "datePosted": "2026-10-01",
+      "validThrough": "2026-11-30T00:00:00+01:00"
+    }
+  ]
+}
+</script>
+</head><body></body></html>`
+
+func TestParseJSONLD(t *testing.T) {
+	p, err := Parse(strings.NewReader(jsonLDPage), "https://jobs.example.com/listing?id=42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checks := []struct{ field, got, want string }{
+		{"Role", p.Role, "Backend Engineer"},
+		{"Company", p.Company, "Acme & Sons"},
+		{"Location", p.Location, "Berlin, DE; Munich, DE (remote)"},
+		{"URL", p.URL, "https://jobs.example.com/jobs/42"},
+		{"Description", p.Description, "Build & run APIs.\n\n- Go\n- SQL"},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %q, want %q", c.field, c.got, c.want)
+		}
+	}
+
+	want := models.Compensation{Min: 60000, Max: 70000, Currency: "EUR", Period: models.PeriodYear}
+	if c := p.Compensation; c == nil || c.Min != want.Min || c.Max != want.Max || c.Currency != want.Currency || c.Period != want.Period {
+		t.Errorf("Compensation = %+v, want %+v", c, want)
+	}
+	if p.DatePosted == nil || !p.DatePosted.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("DatePosted = %v", p.DatePosted)
+	}
+	// Dates with an offset come back in UTC, which is how they are stored
+	if p.ValidThrough == nil || *p.ValidThrough != time.Date(2026, 11, 29, 23, 0, 0, 0, time.UTC) {
+		t.Errorf("ValidThrough = %v, want 2026-11-29 23:00 UTC", p.ValidThrough)
+	}
+}
+
+func TestParseFallbacks(t *testing.T) {
+	tests := []struct {
+		name string
+		page string
+		want Posting
+	}{
+		{
+			"OpenGraph",
+			`<html><head>
+			<title>Page title</title>
+			<meta property="og:title" content="Data Analyst">
+			<meta property="og:site_name" content="Initech">
+			<meta property="og:description" content="Crunch numbers.">
+			<meta name="description" content="Not this one">
+			<meta property="og:url" content="https://initech.example/careers/7">
+			</head></html>`,
+			Posting{Role: "Data Analyst", Company: "Initech", Description: "Crunch numbers.", URL: "https://initech.example/careers/7"},
+		},
+		{
+			"meta and title",
+			`<html><head>
+			<title> Support Engineer </title>
+			<meta name="description" content="Help customers.">
+			</head></html>`,
+			Posting{Role: "Support Engineer", Description: "Help customers.", URL: "https://example.com/job"},
+		},
+		{
+			"broken JSON-LD",
+			`<html><head>
+			<script type="application/ld+json">{"@type": "JobPosting", "title": </script>
+			<meta property="og:title" content="Designer">
+			</head></html>`,
+			Posting{Role: "Designer", URL: "https://example.com/job"},
+		},
+		{
+			"JSON-LD gaps",
+			`<html><head>
+			<script type="application/ld+json">{"@type": "JobPosting", "title": "QA Lead", "baseSalary": "$50-60k per year"}</script>
+			<meta property="og:site_name" content="Globex">
+			<meta property="og:description" content="Test things.">
+			</head></html>`,
+			Posting{Role: "QA Lead", Company: "Globex", Description: "Test things.", URL: "https://example.com/job"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Parse(strings.NewReader(tt.page), "https://example.com/job")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Role != tt.want.Role || p.Company != tt.want.Company || p.Description != tt.want.Description || p.URL != tt.want.URL {
+				t.Errorf("Parse() = {Role:%q Company:%q Description:%q URL:%q}, want {Role:%q Company:%q Description:%q URL:%q}",
+					p.Role, p.Company, p.Description, p.URL, tt.want.Role, tt.want.Company, tt.want.Description, tt.want.URL)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2026-11-30", time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC)},
+		{"2026-11-30T09:30:00", time.Date(2026, 11, 30, 9, 30, 0, 0, time.UTC)},
+		{"2026-11-30T09:30:00Z", time.Date(2026, 11, 30, 9, 30, 0, 0, time.UTC)},
+		{"2026-11-30T09:30:00-05:00", time.Date(2026, 11, 30, 14, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got := parseDate(tt.in)
+		if got == nil || *got != tt.want || got.Location() != time.UTC {
+			t.Errorf("parseDate(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	for _, in := range []string{"", "30/11/2026", "soon"} {
+		if got := parseDate(in); got != nil {
+			t.Errorf("parseDate(%q) = %v, want nil", in, got)
+		}
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	in := `<h2>About</h2><p>We   build things.</p><script>alert(1)</script><p>Line one<br>Line two</p><ol><li>First</li><li>Second</li></ol>`
+	want := "About\nWe build things.\nLine one\nLine two\n\n- First\n- Second"
+	if got := HTMLToText(in); got != want {
+		t.Errorf("HTMLToText() = %q, want %q", got, want)
+	}
+}
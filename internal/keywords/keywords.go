@@ -0,0 +1,258 @@
+// Package keywords compares a CV with a job description the way applicant
+// tracking systems do: by the keywords they share.
+package keywords
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// maxListed caps the matched and missing keywords reported.
+const maxListed = 50
+
+// Match compares a CV with a job description. Keywords are weighted by how
+// often the job description uses them; the score is the share of that
+// weight the CV covers, from 0 to 100.
+func Match(cv models.CVData, jobDescription string) models.KeywordMatch {
+	jd := Tokenize(jobDescription)
+	have := map[string]bool{}
+	for _, t := range Tokenize(CVText(cv)) {
+		have[t] = true
+	}
+
+	counts := map[string]int{}
+	var order []string
+	for _, t := range jd {
+		if stopwords[t] || isNumber(t) || len([]rune(t)) < 2 && !shortTerms[t] {
+			continue
+		}
+		if counts[t] == 0 {
+			order = append(order, t)
+		}
+		counts[t]++
+	}
+
+	res := models.KeywordMatch{
+		Matched: []models.KeywordCount{},
+		Missing: []models.KeywordCount{},
+		Skills:  []models.SkillCoverage{},
+	}
+	var total, matched int
+	for _, t := range order {
+		kc := models.KeywordCount{Keyword: t, Count: counts[t]}
+		total += kc.Count
+		if have[t] {
+			matched += kc.Count
+			res.Matched = append(res.Matched, kc)
+		} else {
+			res.Missing = append(res.Missing, kc)
+		}
+	}
+	if total > 0 {
+		res.Score = int(math.Round(float64(matched) / float64(total) * 100))
+	}
+	res.Matched = topKeywords(res.Matched)
+	res.Missing = topKeywords(res.Missing)
+
+	for _, g := range cv.Skills {
+		sc := models.SkillCoverage{Category: g.Category, Mentioned: []string{}, NotMentioned: []string{}}
+		for _, s := range g.Items {
+			if s.Name == "" {
+				continue
+			}
+			if containsPhrase(jd, Tokenize(s.Name)) {
+				sc.Mentioned = append(sc.Mentioned, s.Name)
+			} else {
+				sc.NotMentioned = append(sc.NotMentioned, s.Name)
+			}
+		}
+		res.SkillsMentioned += len(sc.Mentioned)
+		res.SkillsTotal += len(sc.Mentioned) + len(sc.NotMentioned)
+		res.Skills = append(res.Skills, sc)
+	}
+	return res
+}
+
+// topKeywords sorts keywords by count, keeping first-seen order among
+// equals, and keeps the first maxListed.
+func topKeywords(kws []models.KeywordCount) []models.KeywordCount {
+	sort.SliceStable(kws, func(i, j int) bool { return kws[i].Count > kws[j].Count })
+	if len(kws) > maxListed {
+		kws = kws[:maxListed]
+	}
+	return kws
+}
+
+// containsPhrase reports whether phrase occurs as consecutive tokens.
+func containsPhrase(tokens, phrase []string) bool {
+	if len(phrase) == 0 {
+		return false
+	}
+	for i := 0; i+len(phrase) <= len(tokens); i++ {
+		match := true
+		for j, p := range phrase {
+			if tokens[i+j] != p {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// CVText gathers the wording of a CV.
+func CVText(cv models.CVData) string {
+	parts := []string{cv.Personal.Title, cv.Summary}
+	for _, e := range cv.Experience {
+		parts = append(parts, e.Title, e.Company, e.Description)
+		for _, b := range e.Bullets {
+			parts = append(parts, b.Text)
+		}
+		parts = append(parts, e.Tags...)
+	}
+	for _, e := range cv.Education {
+		parts = append(parts, e.Degree, e.Field, e.Institution, e.Description)
+	}
+	for _, g := range cv.Skills {
+		parts = append(parts, g.Category)
+		for _, s := range g.Items {
+			parts = append(parts, s.Name)
+		}
+	}
+	for _, l := range cv.Languages {
+		parts = append(parts, l.Language)
+	}
+	for _, c := range cv.Certifications {
+		parts = append(parts, c.Name, c.Issuer)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// Tokenize splits text into normalized terms: lower-cased, with plurals
+// reduced and common spellings of technologies unified ("Golang" and "Go",
+// "front-end" and "frontend"). Names such as "C++", "C#", ".NET" and
+// "Node.js" are kept whole.
+func Tokenize(text string) []string {
+	var tokens []string
+	for _, field := range strings.FieldsFunc(strings.ToLower(text), isSeparator) {
+		if t := normalize(field); t != "" {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
+
+func isSeparator(r rune) bool {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) {
+		return false
+	}
+	switch r {
+	case '+', '#', '.', '-':
+		return false
+	}
+	return true
+}
+
+func normalize(t string) string {
+	// Sentence punctuation, but not the dot of ".net"
+	t = strings.TrimRight(t, ".-")
+	if !strings.HasPrefix(t, ".") {
+		t = strings.TrimLeft(t, "-+#")
+	}
+	if t == "" || t == "." {
+		return ""
+	}
+	if a, ok := aliases[t]; ok {
+		return a
+	}
+	t = strings.ReplaceAll(t, "-", "")
+	if a, ok := aliases[t]; ok {
+		return a
+	}
+	return stem(t)
+}
+
+// stem reduces English plurals, leaving words that only look plural alone.
+func stem(t string) string {
+	if unstemmed[t] {
+		return t
+	}
+	n := len(t)
+	switch {
+	case n > 4 && strings.HasSuffix(t, "ies"):
+		return t[:n-3] + "y"
+	case n > 3 && strings.HasSuffix(t, "s") && !strings.ContainsAny(t, ".+#") &&
+		!strings.HasSuffix(t, "ss") && !strings.HasSuffix(t, "us") && !strings.HasSuffix(t, "is") &&
+		!strings.HasSuffix(t, "ys"):
+		return t[:n-1]
+	}
+	return t
+}
+
+func isNumber(t string) bool {
+	for _, r := range t {
+		if !unicode.IsDigit(r) && r != '.' && r != '+' {
+			return false
+		}
+	}
+	return true
+}
+
+// shortTerms are one-letter terms that are still keywords.
+var shortTerms = map[string]bool{"c": true, "r": true}
+
+// aliases unify common spellings of the same technology.
+var aliases = map[string]string{
+	"golang":   "go",
+	"js":       "javascript",
+	"ts":       "typescript",
+	"k8s":      "kubernetes",
+	"postgres": "postgresql",
+	"node":     "node.js",
+	"nodejs":   "node.js",
+	"react.js": "react",
+	"reactjs":  "react",
+	"vue.js":   "vue",
+	"vuejs":    "vue",
+	"dotnet":   ".net",
+}
+
+// unstemmed are names that only look plural.
+var unstemmed = map[string]bool{
+	"kubernetes": true, "jenkins": true, "express": true, "rails": true, "pandas": true,
+	"windows": true, "analytics": true, "sass": true, "less": true, "graphics": true,
+}
+
+// stopwords are common English words and job-posting boilerplate that say
+// nothing about the role.
+var stopwords = setOf(`
+a about above after again against all also am an and any are as at be because been before being
+below between both but by can could did do does doing down during each few for from further had has
+have having he her here hers him his how i if in into is it its itself just me more most my no nor
+not now of off on once only or other our ours out over own same she should so some such than that
+the their them then there these they this those through to too under until up very was we were what
+when where which while who whom why will with would you your yours within without across via per
+etc e.g i.e eg ie us may might must shall get got make made well new use using used like
+experience experienced work working job role position team teams company candidate candidates
+opportunity opportunities looking join ability able strong good great excellent solid proven
+knowledge understanding skill skills year years plus bonus preferred required requirement
+requirements responsibility responsibilities qualification qualifications including include
+includes ideal ideally help helping want need needs needed offer offering apply applicant day days
+environment based related relevant familiarity familiar demonstrated minimum least equivalent
+`)
+
+func setOf(words string) map[string]bool {
+	set := map[string]bool{}
+	for _, w := range strings.Fields(words) {
+		set[stem(w)] = true
+		set[w] = true
+	}
+	return set
+}
@@ -0,0 +1,58 @@
+package keywords
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"Golang, Go and K8s.", []string{"go", "go", "and", "kubernetes"}},
+		{"C++ / C# / .NET developers", []string{"c++", "c#", ".net", "developer"}},
+		{"Node.js, NodeJS and node", []string{"node.js", "node.js", "and", "node.js"}},
+		{"Front-end services; libraries.", []string{"frontend", "service", "library"}},
+		{"React.js (ReactJS) or Vue.js, dotnet", []string{"react", "react", "or", "vue", ".net"}},
+		{"Kubernetes, Jenkins, pandas", []string{"kubernetes", "jenkins", "pandas"}},
+		{"class, status, analysis, keys, bus", []string{"class", "status", "analysis", "keys", "bus"}},
+		{"--verbose ... - +", []string{"verbose"}},
+		{"5+ years in R", []string{"5+", "year", "in", "r"}},
+		{"Entwicklung für Größe", []string{"entwicklung", "für", "größe"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := Tokenize(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Tokenize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	cv := models.CVData{
+		Experience: []models.Experience{{Title: "Developer", Bullets: []models.Bullet{{Text: "Built services in Golang"}}}},
+		Skills: []models.SkillGroup{{Category: "Backend", Items: []models.Skill{
+			{Name: "Go"}, {Name: "PostgreSQL"}, {Name: "Docker"},
+		}}},
+	}
+	jd := "We need a Go engineer. Go, Postgres and Kubernetes are required, with 5 years of experience building services."
+
+	got := Match(cv, jd)
+	want := models.KeywordMatch{
+		// Four of the seven keyword uses are covered
+		Score:   57,
+		Matched: []models.KeywordCount{{Keyword: "go", Count: 2}, {Keyword: "postgresql", Count: 1}, {Keyword: "service", Count: 1}},
+		Missing: []models.KeywordCount{{Keyword: "engineer", Count: 1}, {Keyword: "kubernetes", Count: 1}, {Keyword: "building", Count: 1}},
+		Skills: []models.SkillCoverage{
+			{Category: "Backend", Mentioned: []string{"Go", "PostgreSQL"}, NotMentioned: []string{"Docker"}},
+		},
+		SkillsMentioned: 2,
+		SkillsTotal:     3,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Match() = %+v\nwant %+v", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// ActivityReport lists what a user did to find work over a period, in the
+// shape benefits agencies ask for: the applications made, the contact with
+// employers since, and a summary.
+type ActivityReport struct {
+	From         time.Time             `json:"from"` // First day of the period
+	To           time.Time             `json:"to"`   // Last day of the period, inclusive
+	GeneratedAt  time.Time             `json:"generatedAt"`
+	Name         string                `json:"name"`
+	Email        string                `json:"email"`
+	Applications []ActivityApplication `json:"applications"` // Made in the period, by date
+	Activities   []Activity            `json:"activities"`   // Interviews, calls, emails and status changes in the period, by date
+	Summary      ActivitySummary       `json:"summary"`
+}
+
+// ActivityApplication is an application made in the period of a report.
+type ActivityApplication struct {
+	ApplicationID string            `json:"applicationId"`
+	Date          time.Time         `json:"date"`
+	Company       string            `json:"company"`
+	Role          string            `json:"role"`
+	Location      string            `json:"location"`
+	URL           string            `json:"url"`
+	ContactMethod string            `json:"contactMethod"` // The source, or the site of the posting
+	Outcome       ApplicationStatus `json:"outcome"`       // Status at the end of the period
+	Category      StageCategory     `json:"category"`
+}
+
+// Activity is contact with an employer during the period of a report, on
+// an application made in it or before.
+type Activity struct {
+	ApplicationID string    `json:"applicationId"`
+	Date          time.Time `json:"date"`
+	Company       string    `json:"company"`
+	Role          string    `json:"role"`
+	Type          EventType `json:"type"` // call, email, interview or status_change
+	Description   string    `json:"description"`
+}
+
+// ActivitySummary totals a report.
+type ActivitySummary struct {
+	Applications int            `json:"applications"`
+	Companies    int            `json:"companies"`  // Distinct companies applied to
+	Interviews   int            `json:"interviews"` // Scheduled rounds and logged interviews, once per application and day
+	Contacts     int            `json:"contacts"`   // Logged calls and emails
+	Outcomes     []OutcomeCount `json:"outcomes"`   // Of the applications made, in pipeline order
+	PerWeek      []WeekCount    `json:"perWeek"`
+}
+
+// OutcomeCount is the number of applications in a status.
+type OutcomeCount struct {
+	Status   ApplicationStatus `json:"status"`
+	Category StageCategory     `json:"category"`
+	Count    int               `json:"count"`
+}
@@ -0,0 +1,204 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// DefaultStaleAfter is how long an open application may go without activity
+// before it counts as stale.
+const DefaultStaleAfter = 14 * 24 * time.Hour
+
+// ApplicationAnalytics summarises a user's job search.
+type ApplicationAnalytics struct {
+	Total                int                `json:"total"`
+	Funnel               []FunnelStep       `json:"funnel"`
+	Stages               []StageCount       `json:"stages"` // In pipeline order
+	Responded            int                `json:"responded"`
+	MedianDaysToResponse *float64           `json:"medianDaysToResponse"` // Null until an application got a response
+	PerWeek              []WeekCount        `json:"perWeek"`
+	ByCompany            []OutcomeBreakdown `json:"byCompany"`
+	BySource             []OutcomeBreakdown `json:"bySource"`
+	Stale                int                `json:"stale"`
+	StaleAfterDays       int                `json:"staleAfterDays"`
+}
+
+// FunnelStep counts the applications that got at least as far as a stage
+// category: applied, then interview, then offer.
+type FunnelStep struct {
+	Category   StageCategory `json:"category"`
+	Reached    int           `json:"reached"`
+	Conversion float64       `json:"conversion"` // Share of the previous step that reached this one
+}
+
+// StageCount counts the applications in a pipeline stage, now and ever.
+type StageCount struct {
+	Stage    string        `json:"stage"`
+	Category StageCategory `json:"category"`
+	Current  int           `json:"current"`
+	Reached  int           `json:"reached"`
+}
+
+// WeekCount is the number of applications sent in a week.
+type WeekCount struct {
+	Week  string `json:"week"` // Monday of the week, YYYY-MM-DD
+	Count int    `json:"count"`
+}
+
+// OutcomeBreakdown counts applications by the category of their current
+// stage, for one company or source.
+type OutcomeBreakdown struct {
+	Key       string `json:"key"` // Empty for applications without a source
+	Total     int    `json:"total"`
+	Applied   int    `json:"applied"`
+	Interview int    `json:"interview"`
+	Offer     int    `json:"offer"`
+	Rejected  int    `json:"rejected"`
+	Closed    int    `json:"closed"`
+}
+
+// Add counts n applications whose current stage is in category c.
+func (b *OutcomeBreakdown) Add(c StageCategory, n int) {
+	b.Total += n
+	switch c {
+	case CategoryInterview:
+		b.Interview += n
+	case CategoryOffer:
+		b.Offer += n
+	case CategoryRejected:
+		b.Rejected += n
+	case CategoryClosed:
+		b.Closed += n
+	default:
+		b.Applied += n
+	}
+}
+
+// CVEffectiveness reports how applications sent with each CV and CV
+// version fared.
+type CVEffectiveness struct {
+	Overall OutcomeRates    `json:"overall"` // All applications linked to a CV
+	CVs     []CVPerformance `json:"cvs"`     // Most used first
+}
+
+// CVPerformance is the outcome of the applications sent with one CV,
+// compared against applications sent with other CVs.
+type CVPerformance struct {
+	CVID         string                 `json:"cvId"`
+	Title        string                 `json:"title"`
+	Outcomes     OutcomeRates           `json:"outcomes"`
+	Significance SignificanceHint       `json:"significance"`
+	Versions     []CVVersionPerformance `json:"versions"` // Compared against the other versions of the CV
+}
+
+// CVVersionPerformance is the outcome of the applications sent with one
+// version of a CV.
+type CVVersionPerformance struct {
+	VersionID    *string          `json:"versionId"` // Null for applications linked to the live CV
+	Message      string           `json:"message"`
+	CreatedAt    *time.Time       `json:"createdAt"`
+	Outcomes     OutcomeRates     `json:"outcomes"`
+	Significance SignificanceHint `json:"significance"`
+}
+
+// OutcomeRates counts applications and how many got to an interview or an
+// offer.
+type OutcomeRates struct {
+	Applications  int     `json:"applications"`
+	Interviews    int     `json:"interviews"` // Reached an interview stage or further
+	Offers        int     `json:"offers"`
+	InterviewRate float64 `json:"interviewRate"`
+	OfferRate     float64 `json:"offerRate"`
+}
+
+// NewOutcomeRates returns the rates for the given counts.
+func NewOutcomeRates(applications, interviews, offers int) OutcomeRates {
+	r := OutcomeRates{Applications: applications, Interviews: interviews, Offers: offers}
+	if applications > 0 {
+		r.InterviewRate = math.Round(float64(interviews)/float64(applications)*1000) / 1000
+		r.OfferRate = math.Round(float64(offers)/float64(applications)*1000) / 1000
+	}
+	return r
+}
+
+// Without returns the rates of r with the applications of o taken out.
+func (r OutcomeRates) Without(o OutcomeRates) OutcomeRates {
+	return NewOutcomeRates(r.Applications-o.Applications, r.Interviews-o.Interviews, r.Offers-o.Offers)
+}
+
+// Significance says whether a difference in interview rates is likely real.
+type Significance string
+
+const (
+	SignificanceTooFew Significance = "insufficient_data"
+	SignificanceNone   Significance = "no_difference" // Could be chance
+	SignificanceBetter Significance = "better"
+	SignificanceWorse  Significance = "worse"
+)
+
+// Thresholds for significance hints.
+const (
+	minSignificanceSample = 5
+	significanceLevel     = 0.05
+)
+
+// SignificanceHint compares the interview rate of a group of applications
+// with the rest.
+type SignificanceHint struct {
+	Significance Significance `json:"significance"`
+	PValue       *float64     `json:"pValue"` // Two-sided Fisher's exact test; null with too little data
+	Hint         string       `json:"hint"`
+}
+
+// CompareInterviewRates tests whether group gets interviews at a different
+// rate than rest. Offers are too rare in a job search to test on.
+func CompareInterviewRates(group, rest OutcomeRates) SignificanceHint {
+	if group.Applications < minSignificanceSample || rest.Applications < minSignificanceSample {
+		return SignificanceHint{
+			Significance: SignificanceTooFew,
+			Hint:         fmt.Sprintf("Too few applications to tell; at least %d on each side are needed", minSignificanceSample),
+		}
+	}
+	p := fisherExact(group.Interviews, group.Applications-group.Interviews,
+		rest.Interviews, rest.Applications-rest.Interviews)
+	p = math.Round(p*1000) / 1000
+	h := SignificanceHint{PValue: &p}
+	rates := fmt.Sprintf("Interview rate %.0f%% against %.0f%% for the rest", group.InterviewRate*100, rest.InterviewRate*100)
+	switch {
+	case p >= significanceLevel:
+		h.Significance = SignificanceNone
+		h.Hint = rates + "; the difference could be chance"
+	case group.InterviewRate > rest.InterviewRate:
+		h.Significance = SignificanceBetter
+		h.Hint = rates + "; unlikely to be chance"
+	default:
+		h.Significance = SignificanceWorse
+		h.Hint = rates + "; unlikely to be chance"
+	}
+	return h
+}
+
+// fisherExact returns the two-sided p-value of Fisher's exact test for the
+// 2x2 table [[a, b], [c, d]].
+func fisherExact(a, b, c, d int) float64 {
+	row1, col1, n := a+b, a+c, a+b+c+d
+	lchoose := func(n, k int) float64 {
+		x, _ := math.Lgamma(float64(n + 1))
+		y, _ := math.Lgamma(float64(k + 1))
+		z, _ := math.Lgamma(float64(n - k + 1))
+		return x - y - z
+	}
+	// Probability of the table with x in the top-left cell, margins fixed
+	prob := func(x int) float64 {
+		return math.Exp(lchoose(col1, x) + lchoose(n-col1, row1-x) - lchoose(n, row1))
+	}
+	observed := prob(a)
+	p := 0.0
+	for x := max(0, row1+col1-n); x <= min(row1, col1); x++ {
+		if px := prob(x); px <= observed*(1+1e-7) {
+			p += px
+		}
+	}
+	return math.Min(p, 1)
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFisherExact(t *testing.T) {
+	tests := []struct {
+		a, b, c, d int
+		want       float64
+	}{
+		{3, 1, 1, 3, 0.4857142857},  // Fisher's tea tasting
+		{1, 9, 11, 3, 0.0027594562}, // Dieting example from R's fisher.test
+		{10, 0, 0, 10, 1.0825088e-05},
+		{0, 5, 5, 0, 0.0079365079},
+		{2, 8, 7, 3, 0.0697785187},
+		{8, 12, 6, 24, 0.1980975385},
+		{5, 5, 5, 5, 1},
+		{0, 0, 3, 4, 1},
+	}
+	for _, tt := range tests {
+		got := fisherExact(tt.a, tt.b, tt.c, tt.d)
+		if math.Abs(got-tt.want) > 1e-9+tt.want*1e-6 {
+			t.Errorf("fisherExact(%d, %d, %d, %d) = %v, want %v", tt.a, tt.b, tt.c, tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestCompareInterviewRates(t *testing.T) {
+	tests := []struct {
+		name        string
+		group, rest OutcomeRates
+		want        Significance
+		pValue      float64
+	}{
+		{"too few", NewOutcomeRates(4, 4, 0), NewOutcomeRates(20, 0, 0), SignificanceTooFew, 0},
+		{"chance", NewOutcomeRates(10, 2, 0), NewOutcomeRates(10, 7, 0), SignificanceNone, 0.07},
+		{"better", NewOutcomeRates(10, 10, 0), NewOutcomeRates(10, 0, 0), SignificanceBetter, 0},
+		{"worse", NewOutcomeRates(10, 1, 0), NewOutcomeRates(14, 11, 0), SignificanceWorse, 0.003},
+	}
+	for _, tt := range tests {
+		h := CompareInterviewRates(tt.group, tt.rest)
+		if h.Significance != tt.want {
+			t.Errorf("%s: Significance = %s, want %s", tt.name, h.Significance, tt.want)
+		}
+		switch {
+		case tt.want == SignificanceTooFew && h.PValue != nil:
+			t.Errorf("%s: PValue = %v, want none", tt.name, *h.PValue)
+		case tt.want != SignificanceTooFew && (h.PValue == nil || *h.PValue != tt.pValue):
+			t.Errorf("%s: PValue = %v, want %v", tt.name, h.PValue, tt.pValue)
+		}
+		if h.Hint == "" {
+			t.Errorf("%s: no hint", tt.name)
+		}
+	}
+}
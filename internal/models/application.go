@@ -2,54 +2,163 @@ package models
 
 import "time"
 
-// ApplicationStatus represents the status of a job application.
+// ApplicationStatus represents the status of a job application. It holds
+// the name of one of the stages of the user's pipeline.
 type ApplicationStatus string
 
+// StageCategory says what a pipeline stage means for the search, so that
+// reports work the same across differently named custom pipelines.
+type StageCategory string
+
 const (
-	StatusApplied      ApplicationStatus = "Applied"
-	StatusInterviewing ApplicationStatus = "Interviewing"
-	StatusRejected     ApplicationStatus = "Rejected"
-	StatusOffer        ApplicationStatus = "Offer"
+	CategoryApplied   StageCategory = "applied"   // Submitted, waiting for an answer
+	CategoryInterview StageCategory = "interview" // In the hiring process
+	CategoryOffer     StageCategory = "offer"
+	CategoryRejected  StageCategory = "rejected"
+	CategoryClosed    StageCategory = "closed" // Withdrawn, ghosted, position closed
 )
 
+// Valid reports whether c is one of the known stage categories.
+func (c StageCategory) Valid() bool {
+	switch c {
+	case CategoryApplied, CategoryInterview, CategoryOffer, CategoryRejected, CategoryClosed:
+		return true
+	}
+	return false
+}
+
+// PipelineStage is one status of a user's application pipeline.
+type PipelineStage struct {
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	Position int           `json:"position"`
+	Terminal bool          `json:"terminal"` // No further progress expected
+	Color    string        `json:"color"`    // Hex color, e.g. "#3b82f6"
+	Category StageCategory `json:"category"`
+}
+
+// UpdatePipelineRequest replaces a user's pipeline. Stages are stored in the
+// given order; stages with an ID update (and rename) existing ones.
+type UpdatePipelineRequest struct {
+	Stages []PipelineStage `json:"stages"`
+}
+
+// DefaultPipeline returns the stages every user starts with.
+func DefaultPipeline() []PipelineStage {
+	return []PipelineStage{
+		{Name: "Applied", Color: "#3b82f6", Category: CategoryApplied},
+		{Name: "Interviewing", Color: "#f59e0b", Category: CategoryInterview},
+		{Name: "Offer", Color: "#10b981", Category: CategoryOffer, Terminal: true},
+		{Name: "Rejected", Color: "#ef4444", Category: CategoryRejected, Terminal: true},
+	}
+}
+
+// FindStage returns the stage with the given name, or nil.
+func FindStage(stages []PipelineStage, status ApplicationStatus) *PipelineStage {
+	for i := range stages {
+		if stages[i].Name == string(status) {
+			return &stages[i]
+		}
+	}
+	return nil
+}
+
 // Application represents a job application.
 type Application struct {
-	ID          string            `json:"id"`
-	Company     string            `json:"company"`
-	Role        string            `json:"role"`
-	Status      ApplicationStatus `json:"status"`
-	Salary      string            `json:"salary"`
-	URL         string            `json:"url"`
-	Date        time.Time         `json:"date"`
-	Notes       string            `json:"notes"`
-	CVID        *string           `json:"cvId"`        // Nullable
-	CVVersionID *string           `json:"cvVersionId"` // Nullable
-	CreatedAt   time.Time         `json:"createdAt"`
-	UpdatedAt   time.Time         `json:"updatedAt"`
+	ID           string            `json:"id"`
+	CompanyID    *string           `json:"companyId"` // Nullable for applications predating companies
+	Company      string            `json:"company"`   // Name of the company
+	Role         string            `json:"role"`
+	Status       ApplicationStatus `json:"status"`
+	Compensation *Compensation     `json:"compensation"` // Nullable
+	URL          string            `json:"url"`
+	Source       string            `json:"source"` // Where the posting was found, e.g. "LinkedIn" or "Referral"
+	Location     string            `json:"location"`
+	Date         time.Time         `json:"date"`
+	Notes        string            `json:"notes"`
+	Deadline     *time.Time        `json:"deadline"`    // Nullable; closing date of the posting
+	FollowUpAt   *time.Time        `json:"followUpAt"`  // Nullable; when to chase the application
+	CVID         *string           `json:"cvId"`        // Nullable
+	CVVersionID  *string           `json:"cvVersionId"` // Nullable
+	CreatedAt    time.Time         `json:"createdAt"`
+	UpdatedAt    time.Time         `json:"updatedAt"`
+
+	Attachments []Attachment `json:"attachments,omitempty"` // Only set on single fetch
 }
 
 // CreateApplicationRequest is the payload for creating an application.
 type CreateApplicationRequest struct {
-	Company     string            `json:"company"`
-	Role        string            `json:"role"`
-	Status      ApplicationStatus `json:"status"`
-	Salary      string            `json:"salary"`
-	URL         string            `json:"url"`
-	Date        time.Time         `json:"date"`
-	Notes       string            `json:"notes"`
-	CVID        *string           `json:"cvId"`
-	CVVersionID *string           `json:"cvVersionId"`
+	CompanyID    *string           `json:"companyId"` // Takes precedence over Company
+	Company      string            `json:"company"`   // Matched to a company, or creates one
+	Role         string            `json:"role"`
+	Status       ApplicationStatus `json:"status"`
+	Compensation *Compensation     `json:"compensation"`
+	Salary       string            `json:"salary"` // Deprecated: parsed into Compensation when that is not set
+	URL          string            `json:"url"`
+	Source       string            `json:"source"`
+	Location     string            `json:"location"`
+	Date         time.Time         `json:"date"`
+	Notes        string            `json:"notes"`
+	Deadline     *time.Time        `json:"deadline"`
+	FollowUpAt   *time.Time        `json:"followUpAt"`
+	CVID         *string           `json:"cvId"`
+	CVVersionID  *string           `json:"cvVersionId"`
 }
 
 // UpdateApplicationRequest is the payload for updating an application.
 type UpdateApplicationRequest struct {
-	Company     string            `json:"company"`
-	Role        string            `json:"role"`
-	Status      ApplicationStatus `json:"status"`
-	Salary      string            `json:"salary"`
-	URL         string            `json:"url"`
-	Date        time.Time         `json:"date"`
-	Notes       string            `json:"notes"`
-	CVID        *string           `json:"cvId"`
-	CVVersionID *string           `json:"cvVersionId"`
+	CompanyID    *string           `json:"companyId"`
+	Company      string            `json:"company"`
+	Role         string            `json:"role"`
+	Status       ApplicationStatus `json:"status"`
+	Compensation *Compensation     `json:"compensation"`
+	Salary       string            `json:"salary"` // Deprecated: parsed into Compensation when that is not set
+	URL          string            `json:"url"`
+	Source       string            `json:"source"`
+	Location     string            `json:"location"`
+	Date         time.Time         `json:"date"`
+	Notes        string            `json:"notes"`
+	Deadline     *time.Time        `json:"deadline"`
+	FollowUpAt   *time.Time        `json:"followUpAt"`
+	CVID         *string           `json:"cvId"`
+	CVVersionID  *string           `json:"cvVersionId"`
+}
+
+// ImportURLRequest is the payload for creating an application from a job
+// posting page.
+type ImportURLRequest struct {
+	URL    string            `json:"url"`
+	Status ApplicationStatus `json:"status"` // Defaults to the first pipeline stage
+}
+
+// AttachmentKind classifies a file attached to an application.
+type AttachmentKind string
+
+const (
+	AttachmentCoverLetter    AttachmentKind = "cover_letter"
+	AttachmentJobDescription AttachmentKind = "job_description"
+	AttachmentOfferLetter    AttachmentKind = "offer_letter"
+	AttachmentAssignment     AttachmentKind = "assignment"
+	AttachmentOther          AttachmentKind = "other"
+)
+
+// Valid reports whether k is one of the known attachment kinds.
+func (k AttachmentKind) Valid() bool {
+	switch k {
+	case AttachmentCoverLetter, AttachmentJobDescription, AttachmentOfferLetter, AttachmentAssignment, AttachmentOther:
+		return true
+	}
+	return false
+}
+
+// Attachment is a file stored alongside an application.
+type Attachment struct {
+	ID            string         `json:"id"`
+	ApplicationID string         `json:"applicationId"`
+	Kind          AttachmentKind `json:"kind"`
+	BlobID        string         `json:"-"`
+	Filename      string         `json:"filename"`
+	ContentType   string         `json:"contentType"`
+	Size          int64          `json:"size"`
+	CreatedAt     time.Time      `json:"createdAt"`
 }
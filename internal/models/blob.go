@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Blob describes a stored binary object such as a profile photo.
+type Blob struct {
+	ID          string    `json:"id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// ExportedBlob carries a blob's content inline in a JSON export.
+type ExportedBlob struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Data        []byte `json:"data"` // base64 in JSON
+}
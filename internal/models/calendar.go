@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// CalendarFeed describes a user's private calendar subscription. The URL
+// carries the secret token and is only returned when the token is issued.
+type CalendarFeed struct {
+	Enabled   bool       `json:"enabled"`
+	URL       string     `json:"url,omitempty"`
+	CreatedAt *time.Time `json:"createdAt"`
+}
@@ -0,0 +1,222 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// Company is an employer that applications are made to.
+type Company struct {
+	ID               string    `json:"id"`
+	Name             string    `json:"name"`
+	Aliases          []string  `json:"aliases"` // Other spellings that resolve to this company
+	Website          string    `json:"website"`
+	Industry         string    `json:"industry"`
+	Size             string    `json:"size"` // Free text, e.g. "51-200"
+	Location         string    `json:"location"`
+	Notes            string    `json:"notes"`
+	ApplicationCount int       `json:"applicationCount"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+
+	Applications []Application `json:"applications,omitempty"` // Only set on single fetch
+}
+
+// CompanyRequest is the payload for creating or updating a company.
+type CompanyRequest struct {
+	Name     string   `json:"name"`
+	Aliases  []string `json:"aliases"`
+	Website  string   `json:"website"`
+	Industry string   `json:"industry"`
+	Size     string   `json:"size"`
+	Location string   `json:"location"`
+	Notes    string   `json:"notes"`
+}
+
+// MergeCompaniesRequest folds the source companies into the target.
+type MergeCompaniesRequest struct {
+	TargetID  string   `json:"targetId"`
+	SourceIDs []string `json:"sourceIds"`
+}
+
+// DuplicateGroup is a set of companies that likely are the same one.
+type DuplicateGroup struct {
+	Companies []Company `json:"companies"`
+}
+
+// legalSuffixes are dropped when comparing company names.
+var legalSuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "llp": true, "lp": true, "ltd": true,
+	"limited": true, "corp": true, "corporation": true, "co": true, "company": true,
+	"plc": true, "gmbh": true, "ag": true, "kg": true, "sa": true, "sl": true, "slu": true,
+	"sas": true, "sarl": true, "srl": true, "spa": true, "bv": true, "nv": true, "oy": true,
+	"ab": true, "as": true, "asa": true, "aps": true, "pty": true, "kk": true,
+}
+
+// foldAccents maps accented Latin letters to their base letter.
+var foldAccents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a", "å", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o", "ø", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ç", "c", "ß", "ss", "æ", "ae", "œ", "oe",
+)
+
+// CompanyKey reduces a company name, alias or website to the form used to
+// match spellings of the same company: "ACME Inc.", "careers.acme.com" and
+// "Acme" all give "acme".
+func CompanyKey(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = foldAccents.Replace(s)
+
+	// A bare domain or URL stands for the company it belongs to
+	if !strings.ContainsRune(s, ' ') && strings.Contains(s, ".") {
+		s = strings.TrimPrefix(strings.TrimPrefix(s, "https://"), "http://")
+		s = strings.TrimPrefix(s, "www.")
+		if i := strings.IndexAny(s, ":/?#"); i >= 0 {
+			s = s[:i]
+		}
+		// The company is named by the registrable domain, not a subdomain
+		// such as careers.acme.com or jobs.acme.co.uk
+		if d, err := publicsuffix.EffectiveTLDPlusOne(s); err == nil {
+			s = d
+		}
+		if labels := strings.Split(s, "."); len(labels) > 1 {
+			s = labels[0]
+		}
+	}
+
+	s = strings.ReplaceAll(s, "&", " and ")
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	// Keep a lone suffix-like word, e.g. a company called "AB"
+	for len(fields) > 1 && legalSuffixes[fields[len(fields)-1]] {
+		fields = fields[:len(fields)-1]
+	}
+	if len(fields) > 1 && fields[0] == "the" {
+		fields = fields[1:]
+	}
+	return strings.Join(fields, " ")
+}
+
+// Keys returns the keys the company is known by: those of its name,
+// aliases and website.
+func (c Company) Keys() []string {
+	var keys []string
+	seen := map[string]bool{}
+	for _, s := range append([]string{c.Name, c.Website}, c.Aliases...) {
+		if k := CompanyKey(s); k != "" && !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// MatchCompany returns the company whose keys include that of name, or nil.
+func MatchCompany(companies []Company, name string) *Company {
+	key := CompanyKey(name)
+	if key == "" {
+		return nil
+	}
+	for i := range companies {
+		for _, k := range companies[i].Keys() {
+			if k == key {
+				return &companies[i]
+			}
+		}
+	}
+	return nil
+}
+
+// FindDuplicateCompanies groups companies sharing a key, or whose keys are
+// within a small edit distance of each other ("initech" and "inittech").
+func FindDuplicateCompanies(companies []Company) []DuplicateGroup {
+	parent := make([]int, len(companies))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	keys := make([][]string, len(companies))
+	for i, c := range companies {
+		keys[i] = c.Keys()
+	}
+	for i := range companies {
+		for j := i + 1; j < len(companies); j++ {
+			if keysMatch(keys[i], keys[j]) {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	byRoot := map[int][]Company{}
+	var roots []int
+	for i, c := range companies {
+		r := find(i)
+		if _, ok := byRoot[r]; !ok {
+			roots = append(roots, r)
+		}
+		byRoot[r] = append(byRoot[r], c)
+	}
+	groups := []DuplicateGroup{}
+	for _, r := range roots {
+		if len(byRoot[r]) > 1 {
+			groups = append(groups, DuplicateGroup{Companies: byRoot[r]})
+		}
+	}
+	return groups
+}
+
+func keysMatch(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y || similarKeys(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// similarKeys allows one edit per five characters, for keys long enough
+// that a typo is more likely than a different company.
+func similarKeys(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	n := max(len(ra), len(rb))
+	if min(len(ra), len(rb)) < 5 {
+		return false
+	}
+	return levenshtein(ra, rb) <= n/5
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
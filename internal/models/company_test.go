@@ -0,0 +1,86 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompanyKey(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Acme", "acme"},
+		{"  ACME Inc. ", "acme"},
+		{"Acme, Ltd", "acme"},
+		{"The Acme Company", "acme"},
+		{"Ácme GmbH", "acme"},
+		{"AB", "ab"},
+		{"Procter & Gamble", "procter and gamble"},
+		{"acme.com", "acme"},
+		{"https://www.acme.com/careers?ref=x", "acme"},
+		{"careers.acme.com", "acme"},
+		{"jobs.acme.co.uk", "acme"},
+		{"acme.com:8443", "acme"},
+		{"boards.greenhouse.io", "greenhouse"},
+		{"jobs.lever.co", "lever"},
+		{"Booking.com", "booking"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := CompanyKey(tt.in); got != tt.want {
+			t.Errorf("CompanyKey(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMatchCompany(t *testing.T) {
+	companies := []Company{
+		{ID: "1", Name: "Acme Inc.", Website: "https://acme.com"},
+		{ID: "2", Name: "Initech", Aliases: []string{"Initech Software"}},
+		{ID: "3", Name: "Careers"},
+	}
+	tests := []struct {
+		name, want string
+	}{
+		{"ACME", "1"},
+		{"careers.acme.com", "1"},
+		{"initech software ltd", "2"},
+		{"Careers", "3"},
+		{"Globex", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if c := MatchCompany(companies, tt.name); c != nil {
+			got = c.ID
+		}
+		if got != tt.want {
+			t.Errorf("MatchCompany(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindDuplicateCompanies(t *testing.T) {
+	companies := []Company{
+		{ID: "1", Name: "Initech"},
+		{ID: "2", Name: "Acme"},
+		{ID: "3", Name: "Inittech LLC"},
+		{ID: "4", Name: "Umbrella", Website: "umbrella.com"},
+		{ID: "5", Name: "IBM"},
+		{ID: "6", Name: "IBX"},
+		{ID: "7", Name: "Umbrella Corporation"},
+	}
+	groups := FindDuplicateCompanies(companies)
+	var got [][]string
+	for _, g := range groups {
+		var ids []string
+		for _, c := range g.Companies {
+			ids = append(ids, c.ID)
+		}
+		got = append(got, ids)
+	}
+	want := [][]string{{"1", "3"}, {"4", "7"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("groups = %v, want %v", got, want)
+	}
+}
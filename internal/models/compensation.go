@@ -0,0 +1,241 @@
+package models
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PayPeriod is the period a salary figure is paid over.
+type PayPeriod string
+
+const (
+	PeriodYear  PayPeriod = "year"
+	PeriodMonth PayPeriod = "month"
+	PeriodWeek  PayPeriod = "week"
+	PeriodDay   PayPeriod = "day"
+	PeriodHour  PayPeriod = "hour"
+)
+
+// Valid reports whether p is one of the known pay periods.
+func (p PayPeriod) Valid() bool {
+	return p.PerYear() > 0
+}
+
+// PerYear returns how many periods make a year of full-time work, or 0 for
+// an unknown period.
+func (p PayPeriod) PerYear() float64 {
+	switch p {
+	case PeriodYear:
+		return 1
+	case PeriodMonth:
+		return 12
+	case PeriodWeek:
+		return 52
+	case PeriodDay:
+		return 260 // 52 weeks of 5 days
+	case PeriodHour:
+		return 2080 // 52 weeks of 40 hours
+	}
+	return 0
+}
+
+// Compensation is the pay of a position. Salary figures are per Period;
+// bonus and equity are yearly estimates in the same currency.
+type Compensation struct {
+	Min      float64   `json:"min"`
+	Max      float64   `json:"max"` // Equal to Min for a single figure
+	Currency string    `json:"currency"`
+	Period   PayPeriod `json:"period"`
+	Bonus    float64   `json:"bonus"`
+	Equity   float64   `json:"equity"`
+	Benefits []string  `json:"benefits"`
+	Note     string    `json:"note"` // Free text, e.g. the original salary string
+}
+
+// AnnualBase returns the midpoint of the salary range over a year.
+func (c Compensation) AnnualBase() float64 {
+	max := c.Max
+	if max == 0 {
+		max = c.Min
+	}
+	return (c.Min + max) / 2 * c.Period.PerYear()
+}
+
+// AnnualTotal returns the yearly base, bonus and equity together.
+func (c Compensation) AnnualTotal() float64 {
+	return c.AnnualBase() + c.Bonus + c.Equity
+}
+
+// String summarises the compensation on one line, e.g.
+// "EUR 50000-60000/year + 5000 bonus".
+func (c Compensation) String() string {
+	if c.Min == 0 && c.Max == 0 {
+		return c.Note
+	}
+	var b strings.Builder
+	if c.Currency != "" {
+		b.WriteString(c.Currency + " ")
+	}
+	b.WriteString(formatAmount(c.Min))
+	if c.Max != 0 && c.Max != c.Min {
+		b.WriteString("-" + formatAmount(c.Max))
+	}
+	if c.Period != "" {
+		b.WriteString("/" + string(c.Period))
+	}
+	if c.Bonus != 0 {
+		b.WriteString(" + " + formatAmount(c.Bonus) + " bonus")
+	}
+	if c.Equity != 0 {
+		b.WriteString(" + " + formatAmount(c.Equity) + " equity")
+	}
+	return b.String()
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// currencySymbols maps symbols seen in salary strings to currency codes.
+// "$" is taken to mean US dollars.
+var currencySymbols = map[string]string{
+	"€": "EUR",
+	"£": "GBP",
+	"$": "USD",
+	"¥": "JPY",
+	"₹": "INR",
+	"₣": "CHF",
+}
+
+// currencyCodes are the codes recognised in salary strings.
+var currencyCodes = map[string]bool{
+	"USD": true, "EUR": true, "GBP": true, "CHF": true, "CAD": true,
+	"AUD": true, "NZD": true, "JPY": true, "SEK": true, "NOK": true,
+	"DKK": true, "PLN": true, "CZK": true, "INR": true, "SGD": true,
+	"BRL": true, "MXN": true, "ZAR": true, "HKD": true, "ILS": true,
+}
+
+var (
+	// An amount with optional thousands separators, decimals and a k/m
+	// multiplier: "50,000", "50.000", "50 000", "1.5k", "60K".
+	amountRe = regexp.MustCompile(`(\d{1,3}(?:[,. \x{00a0}]\d{3})+|\d+)(?:[.,](\d{1,2}))?(\s*[kKmM])?`)
+	wordRe   = regexp.MustCompile(`[a-z]+`)
+)
+
+// ParseSalary interprets a free-text salary such as "€50-60k",
+// "USD 120,000 per year" or "£450/day". It is lenient: whatever cannot be
+// understood is left at its zero value, and the original text is kept in
+// Note. It returns nil for an empty string.
+func ParseSalary(s string) *Compensation {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	c := &Compensation{Period: PeriodYear, Benefits: []string{}, Note: s}
+
+	for sym, code := range currencySymbols {
+		if strings.Contains(s, sym) {
+			c.Currency = code
+			break
+		}
+	}
+	words := wordRe.FindAllString(strings.ToLower(s), -1)
+	for _, w := range words {
+		if code := strings.ToUpper(w); currencyCodes[code] {
+			c.Currency = code
+			break
+		}
+	}
+	c.Period = parsePeriod(words)
+
+	var amounts, mults []float64
+	for _, m := range amountRe.FindAllStringSubmatchIndex(s, -1) {
+		whole := strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, s[m[2]:m[3]])
+		v, err := strconv.ParseFloat(whole, 64)
+		if err != nil {
+			continue
+		}
+		if m[4] >= 0 {
+			frac, _ := strconv.ParseFloat("0."+s[m[4]:m[5]], 64)
+			v += frac
+		}
+		mult := 1.0
+		if m[6] >= 0 {
+			// "60 month" is not 60 million: a multiplier must not start a word
+			next, _ := utf8.DecodeRuneInString(s[m[7]:])
+			if m[7] == len(s) || !unicode.IsLetter(next) {
+				switch strings.ToLower(strings.TrimSpace(s[m[6]:m[7]])) {
+				case "k":
+					mult = 1e3
+				case "m":
+					mult = 1e6
+				}
+			}
+		}
+		amounts = append(amounts, v)
+		mults = append(mults, mult)
+	}
+	if len(amounts) == 0 {
+		return c
+	}
+
+	// In "50-60k" the multiplier applies to both ends
+	if len(amounts) > 1 && mults[0] == 1 && mults[1] != 1 && amounts[0] < 1000 {
+		mults[0] = mults[1]
+	}
+	c.Min = amounts[0] * mults[0]
+	c.Max = c.Min
+	if len(amounts) > 1 && amounts[1]*mults[1] >= c.Min {
+		c.Max = amounts[1] * mults[1]
+	}
+	return c
+}
+
+// parsePeriod finds the pay period among the lower-cased words of a salary
+// string, defaulting to a year.
+func parsePeriod(words []string) PayPeriod {
+	for _, w := range words {
+		switch w {
+		case "hour", "hourly", "hr", "hrs", "h", "ph":
+			return PeriodHour
+		case "day", "daily", "days", "pd":
+			return PeriodDay
+		case "week", "weekly", "wk", "pw":
+			return PeriodWeek
+		case "month", "monthly", "months", "mo", "mth", "pcm", "pm":
+			return PeriodMonth
+		}
+	}
+	return PeriodYear
+}
+
+// OfferComparison lines offers up as yearly totals in one currency.
+type OfferComparison struct {
+	Currency string         `json:"currency"`
+	Offers   []OfferSummary `json:"offers"` // Highest total first; offers that cannot be compared last
+}
+
+// OfferSummary is one application's compensation normalized to a year in
+// the comparison currency.
+type OfferSummary struct {
+	ApplicationID string            `json:"applicationId"`
+	Company       string            `json:"company"`
+	Role          string            `json:"role"`
+	Status        ApplicationStatus `json:"status"`
+	Compensation  *Compensation     `json:"compensation"` // As entered
+	AnnualMin     float64           `json:"annualMin"`
+	AnnualMax     float64           `json:"annualMax"`
+	Bonus         float64           `json:"bonus"`
+	Equity        float64           `json:"equity"`
+	AnnualTotal   float64           `json:"annualTotal"` // Midpoint of the range, plus bonus and equity
+	Comparable    bool              `json:"comparable"`
+	Problem       string            `json:"problem,omitempty"` // Why the offer could not be compared
+}
@@ -0,0 +1,51 @@
+package models
+
+import "testing"
+
+func TestParseSalary(t *testing.T) {
+	tests := []struct {
+		in       string
+		currency string
+		min, max float64
+		period   PayPeriod
+	}{
+		{"€50-60k", "EUR", 50000, 60000, PeriodYear},
+		{"USD 120,000 per year", "USD", 120000, 120000, PeriodYear},
+		{"£450/day", "GBP", 450, 450, PeriodDay},
+		{"$45.50 an hour", "USD", 45.5, 45.5, PeriodHour},
+		{"50.000 - 60.000 EUR", "EUR", 50000, 60000, PeriodYear},
+		{"1.5k per month", "", 1500, 1500, PeriodMonth},
+		{"CHF 8 000 monthly", "CHF", 8000, 8000, PeriodMonth},
+		{"60 months", "", 60, 60, PeriodMonth},
+		{"120k-100k", "", 120000, 120000, PeriodYear},
+		{"Competitive", "", 0, 0, PeriodYear},
+	}
+	for _, tt := range tests {
+		c := ParseSalary(tt.in)
+		if c == nil {
+			t.Errorf("ParseSalary(%q) = nil", tt.in)
+			continue
+		}
+		if c.Currency != tt.currency || c.Min != tt.min || c.Max != tt.max || c.Period != tt.period {
+			t.Errorf("ParseSalary(%q) = %s %v-%v/%s, want %s %v-%v/%s",
+				tt.in, c.Currency, c.Min, c.Max, c.Period, tt.currency, tt.min, tt.max, tt.period)
+		}
+		if c.Note != tt.in {
+			t.Errorf("ParseSalary(%q).Note = %q, want the original text", tt.in, c.Note)
+		}
+	}
+
+	if c := ParseSalary("  "); c != nil {
+		t.Errorf("ParseSalary of blank text = %+v, want nil", c)
+	}
+}
+
+func TestCompensationAnnual(t *testing.T) {
+	c := Compensation{Min: 4000, Max: 5000, Period: PeriodMonth, Bonus: 6000, Equity: 1000}
+	if got, want := c.AnnualBase(), 54000.0; got != want {
+		t.Errorf("AnnualBase() = %v, want %v", got, want)
+	}
+	if got, want := c.AnnualTotal(), 61000.0; got != want {
+		t.Errorf("AnnualTotal() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,118 @@
+package models
+
+import "time"
+
+// ContactRelationship is the part a contact plays in an application.
+type ContactRelationship string
+
+const (
+	RelationshipRecruiter     ContactRelationship = "recruiter"
+	RelationshipHiringManager ContactRelationship = "hiring_manager"
+	RelationshipInterviewer   ContactRelationship = "interviewer"
+	RelationshipReferral      ContactRelationship = "referral"
+	RelationshipOther         ContactRelationship = "other"
+)
+
+// Valid reports whether r is one of the known relationships.
+func (r ContactRelationship) Valid() bool {
+	switch r {
+	case RelationshipRecruiter, RelationshipHiringManager, RelationshipInterviewer, RelationshipReferral, RelationshipOther:
+		return true
+	}
+	return false
+}
+
+// InteractionType classifies an entry of a contact's interaction log.
+type InteractionType string
+
+const (
+	InteractionCall    InteractionType = "call"
+	InteractionEmail   InteractionType = "email"
+	InteractionMeeting InteractionType = "meeting"
+	InteractionMessage InteractionType = "message"
+	InteractionOther   InteractionType = "other"
+)
+
+// Valid reports whether t is one of the known interaction types.
+func (t InteractionType) Valid() bool {
+	switch t {
+	case InteractionCall, InteractionEmail, InteractionMeeting, InteractionMessage, InteractionOther:
+		return true
+	}
+	return false
+}
+
+// Contact is a person met during the job search: a recruiter, hiring
+// manager, interviewer or referrer.
+type Contact struct {
+	ID                string     `json:"id"`
+	Name              string     `json:"name"`
+	Company           string     `json:"company"`
+	Role              string     `json:"role"`
+	Email             string     `json:"email"`
+	Phone             string     `json:"phone"`
+	LinkedIn          string     `json:"linkedIn"`
+	Notes             string     `json:"notes"`
+	LastInteractionAt *time.Time `json:"lastInteractionAt"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+
+	// Only set on single fetch
+	Applications []ContactApplication `json:"applications,omitempty"`
+	Interactions []ContactInteraction `json:"interactions,omitempty"`
+}
+
+// ContactRequest is the payload for creating or updating a contact.
+type ContactRequest struct {
+	Name     string `json:"name"`
+	Company  string `json:"company"`
+	Role     string `json:"role"`
+	Email    string `json:"email"`
+	Phone    string `json:"phone"`
+	LinkedIn string `json:"linkedIn"`
+	Notes    string `json:"notes"`
+}
+
+// ContactSearch filters the contact list. Empty fields match everything.
+type ContactSearch struct {
+	Query         string     // Matches name, company, role, email and notes
+	Company       string     // Matches the contact's company or a linked application's
+	ApplicationID string     // Linked to this application
+	From          *time.Time // Has an interaction at or after
+	To            *time.Time // Has an interaction before
+}
+
+// ContactApplication links a contact to an application.
+type ContactApplication struct {
+	ContactID     string              `json:"contactId"`
+	ContactName   string              `json:"contactName"`
+	ApplicationID string              `json:"applicationId"`
+	Company       string              `json:"company"`
+	Role          string              `json:"role"`
+	Status        ApplicationStatus   `json:"status"`
+	Relationship  ContactRelationship `json:"relationship"`
+}
+
+// LinkContactRequest is the payload for linking a contact to an application.
+type LinkContactRequest struct {
+	Relationship ContactRelationship `json:"relationship"`
+}
+
+// ContactInteraction is an entry of a contact's interaction log.
+type ContactInteraction struct {
+	ID            string          `json:"id"`
+	ContactID     string          `json:"contactId"`
+	ApplicationID *string         `json:"applicationId"` // Nullable
+	Type          InteractionType `json:"type"`
+	Summary       string          `json:"summary"`
+	OccurredAt    time.Time       `json:"occurredAt"`
+	CreatedAt     time.Time       `json:"createdAt"`
+}
+
+// InteractionRequest is the payload for logging an interaction.
+type InteractionRequest struct {
+	Type          InteractionType `json:"type"`
+	Summary       string          `json:"summary"`
+	OccurredAt    *time.Time      `json:"occurredAt"` // Defaults to now
+	ApplicationID *string         `json:"applicationId"`
+}
@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// CoverLetterTemplate is a reusable cover letter with merge fields such as
+// {{.Company}}, {{.Role}} and {{.Personal.FirstName}}.
+type CoverLetterTemplate struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CoverLetterTemplateRequest is the payload for creating or updating a
+// cover letter template.
+type CoverLetterTemplateRequest struct {
+	Name string `json:"name"`
+	Body string `json:"body"`
+}
+
+// RenderedCoverLetter is a template filled in for an application.
+type RenderedCoverLetter struct {
+	Body string `json:"body"`
+}
+
+// CoverLetter is the cover letter sent with an application. Its body is
+// plain text, filled in from a template when it was created.
+type CoverLetter struct {
+	ID            string    `json:"id"`
+	ApplicationID string    `json:"applicationId"`
+	TemplateID    *string   `json:"templateId"`  // Nullable; the template it was created from
+	CVID          *string   `json:"cvId"`        // Nullable; the CV its fields came from
+	CVVersionID   *string   `json:"cvVersionId"` // Nullable
+	Title         string    `json:"title"`
+	Body          string    `json:"body"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// CreateCoverLetterRequest is the payload for creating a cover letter for
+// an application, or previewing one. Either Body or TemplateID is required;
+// with only a template, the body is the template filled in for the
+// application.
+type CreateCoverLetterRequest struct {
+	TemplateID  *string `json:"templateId"`
+	CVID        *string `json:"cvId"` // Defaults to the CV linked to the application
+	CVVersionID *string `json:"cvVersionId"`
+	Title       string  `json:"title"`
+	Body        string  `json:"body"`
+}
+
+// UpdateCoverLetterRequest is the payload for updating a cover letter.
+type UpdateCoverLetterRequest struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// CoverLetterVersion is a snapshot of a cover letter.
+type CoverLetterVersion struct {
+	ID            string    `json:"id"`
+	CoverLetterID string    `json:"coverLetterId"`
+	Title         string    `json:"title"`
+	Body          string    `json:"body"`
+	Message       string    `json:"message"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// CoverLetterExport is the JSON export format for a cover letter.
+type CoverLetterExport struct {
+	Title      string               `json:"title"`
+	Body       string               `json:"body"`
+	Company    string               `json:"company"`
+	Role       string               `json:"role"`
+	ExportedAt time.Time            `json:"exportedAt"`
+	Versions   []CoverLetterVersion `json:"versions,omitempty"`
+}
@@ -12,17 +12,28 @@ type PersonalInfo struct {
 	Location  string `json:"location"`
 	LinkedIn  string `json:"linkedin"`
 	Website   string `json:"website"`
+	Photo     string `json:"photo,omitempty"` // Blob ID of the profile photo
 }
 
 // Experience represents a single work experience entry.
 type Experience struct {
-	Company     string `json:"company"`
-	Title       string `json:"title"`
-	Location    string `json:"location"`
-	StartDate   string `json:"startDate"`
-	EndDate     string `json:"endDate"`
-	Current     bool   `json:"current"`
-	Description string `json:"description"`
+	Company     string   `json:"company"`
+	Title       string   `json:"title"`
+	Location    string   `json:"location"`
+	StartDate   string   `json:"startDate"`
+	EndDate     string   `json:"endDate"`
+	Current     bool     `json:"current"`
+	Description string   `json:"description"`
+	Bullets     []Bullet `json:"bullets,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Ref         string   `json:"ref,omitempty"` // Library item this entry is taken from
+}
+
+// Bullet is a single achievement or responsibility of an Experience entry.
+type Bullet struct {
+	Text string   `json:"text"`
+	Tags []string `json:"tags,omitempty"` // Defaults to the entry's tags when empty
+	Ref  string   `json:"ref,omitempty"`  // Library item this bullet is taken from
 }
 
 // Education represents a single education entry.
@@ -37,8 +48,9 @@ type Education struct {
 
 // SkillGroup represents a category of skills.
 type SkillGroup struct {
-	Category string   `json:"category"`
-	Items    []string `json:"items"`
+	Category string  `json:"category"`
+	Items    []Skill `json:"items"`
+	Ref      string  `json:"ref,omitempty"` // Library item this group is taken from
 }
 
 // Language represents a language and proficiency level.
@@ -86,8 +98,10 @@ type SectionLabels struct {
 
 // CVData holds all the structured content of a CV.
 type CVData struct {
+	SchemaVersion  int             `json:"schemaVersion"` // Set on write; see package schema
 	Personal       PersonalInfo    `json:"personal"`
 	Summary        string          `json:"summary"`
+	SummaryRef     string          `json:"summaryRef,omitempty"` // Library item the summary is taken from
 	Experience     []Experience    `json:"experience"`
 	Education      []Education     `json:"education"`
 	Skills         []SkillGroup    `json:"skills"`
@@ -95,6 +109,11 @@ type CVData struct {
 	Certifications []Certification `json:"certifications"`
 	Style          *StyleConfig    `json:"style,omitempty"`
 	Labels         *SectionLabels  `json:"labels,omitempty"`
+
+	// DefaultLocale is the locale of the top-level content; Locales holds
+	// translations of it keyed by locale (e.g. "de", "en-GB").
+	DefaultLocale string                      `json:"defaultLocale,omitempty"`
+	Locales       map[string]LocalizedContent `json:"locales,omitempty"`
 }
 
 // CV represents a complete CV with metadata.
@@ -132,10 +151,77 @@ type CreateVersionRequest struct {
 	Message string `json:"message"`
 }
 
+// AssembleRequest is the request body for assembling a tailored CV from
+// the tagged content of another one.
+type AssembleRequest struct {
+	Tags               []string `json:"tags"` // In priority order
+	MaxPages           float64  `json:"maxPages"`
+	MaxEntries         int      `json:"maxEntries"`
+	MaxBulletsPerEntry int      `json:"maxBulletsPerEntry"`
+	KeepUntagged       bool     `json:"keepUntagged"`
+	Target             string   `json:"target"`  // "cv" (default) or "version"
+	Title              string   `json:"title"`   // Title of the new CV
+	Message            string   `json:"message"` // Message of the new version
+}
+
+// AssembleReport summarises what an assembly kept.
+type AssembleReport struct {
+	KeptEntries    int     `json:"keptEntries"`
+	DroppedEntries int     `json:"droppedEntries"`
+	KeptBullets    int     `json:"keptBullets"`
+	DroppedBullets int     `json:"droppedBullets"`
+	KeptSkills     int     `json:"keptSkills"`
+	DroppedSkills  int     `json:"droppedSkills"`
+	EstimatedPages float64 `json:"estimatedPages"`
+}
+
+// AssembleResponse is the result of an assembly: a new CV or a new version
+// of the source CV.
+type AssembleResponse struct {
+	CV      *CV            `json:"cv,omitempty"`
+	Version *CVVersion     `json:"version,omitempty"`
+	Report  AssembleReport `json:"report"`
+}
+
+// KeywordMatchRequest is the request body for comparing a CV with a job
+// description. The description is either pasted or taken from the job
+// description attached to an application.
+type KeywordMatchRequest struct {
+	VersionID      *string `json:"versionId"`     // Compare a version instead of the live CV
+	ApplicationID  *string `json:"applicationId"` // Used when jobDescription is empty
+	JobDescription string  `json:"jobDescription"`
+}
+
+// KeywordMatch is the result of comparing a CV with a job description.
+type KeywordMatch struct {
+	Score           int             `json:"score"`   // Share of the job description's keywords the CV covers, 0-100
+	Matched         []KeywordCount  `json:"matched"` // Most frequent in the job description first
+	Missing         []KeywordCount  `json:"missing"`
+	Skills          []SkillCoverage `json:"skills"` // One per skill group of the CV
+	SkillsMentioned int             `json:"skillsMentioned"`
+	SkillsTotal     int             `json:"skillsTotal"`
+}
+
+// KeywordCount is a keyword and how often the job description uses it.
+type KeywordCount struct {
+	Keyword string `json:"keyword"`
+	Count   int    `json:"count"`
+}
+
+// SkillCoverage splits the skills of a group by whether the job
+// description mentions them.
+type SkillCoverage struct {
+	Category     string   `json:"category"`
+	Mentioned    []string `json:"mentioned"`
+	NotMentioned []string `json:"notMentioned"`
+}
+
 // CVExport is the JSON export format for a CV.
 type CVExport struct {
-	Title      string      `json:"title"`
-	Data       CVData      `json:"data"`
-	ExportedAt time.Time   `json:"exportedAt"`
-	Versions   []CVVersion `json:"versions,omitempty"`
+	SchemaVersion int           `json:"schemaVersion"`
+	Title         string        `json:"title"`
+	Data          CVData        `json:"data"`
+	ExportedAt    time.Time     `json:"exportedAt"`
+	Versions      []CVVersion   `json:"versions,omitempty"`
+	Photo         *ExportedBlob `json:"photo,omitempty"`
 }
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// cvDateLayouts are the date formats accepted in CV entries. The editor
+// stores "2006-01"; the others show up in imported and hand-written JSON.
+var cvDateLayouts = []string{
+	"2006-01",
+	"2006-01-02",
+	"2006",
+	"01/2006",
+	"Jan 2006",
+	"January 2006",
+}
+
+// ParseCVDate parses a CV entry date such as "2021-04". It returns the first
+// day of the month and false if the value is empty or not a recognised date.
+func ParseCVDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range cvDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC), true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,91 @@
+package models
+
+import "time"
+
+// EventType classifies an entry of an application's timeline.
+type EventType string
+
+const (
+	EventCreated      EventType = "created"
+	EventStatusChange EventType = "status_change"
+	EventFieldChange  EventType = "field_change"
+
+	// Manual events, logged by the user
+	EventCall      EventType = "call"
+	EventEmail     EventType = "email"
+	EventInterview EventType = "interview"
+	EventNote      EventType = "note"
+)
+
+// Manual reports whether t is an event type users may log and delete
+// themselves, as opposed to those recorded on create and update.
+func (t EventType) Manual() bool {
+	switch t {
+	case EventCall, EventEmail, EventInterview, EventNote:
+		return true
+	}
+	return false
+}
+
+// ApplicationEvent is one entry of an application's timeline.
+type ApplicationEvent struct {
+	ID            string    `json:"id"`
+	ApplicationID string    `json:"applicationId"`
+	Type          EventType `json:"type"`
+	Field         string    `json:"field,omitempty"` // JSON name of the changed field
+	From          string    `json:"from,omitempty"`
+	To            string    `json:"to,omitempty"`
+	Note          string    `json:"note,omitempty"`
+	OccurredAt    time.Time `json:"occurredAt"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// CreateEventRequest is the payload for logging a manual event.
+type CreateEventRequest struct {
+	Type       EventType  `json:"type"`
+	Note       string     `json:"note"`
+	OccurredAt *time.Time `json:"occurredAt"` // Defaults to now
+}
+
+// StageSpan is a period an application spent in one status.
+type StageSpan struct {
+	Status    ApplicationStatus `json:"status"`
+	EnteredAt time.Time         `json:"enteredAt"`
+	LeftAt    *time.Time        `json:"leftAt"` // Nil while still in the stage
+	Days      float64           `json:"days"`
+}
+
+// Timeline is the history of an application.
+type Timeline struct {
+	Events []ApplicationEvent `json:"events"`
+	Stages []StageSpan        `json:"stages"`
+}
+
+// StageSpans derives the periods spent in each status from the created and
+// status change events, which must be sorted by OccurredAt. Open spans are
+// measured up to now.
+func StageSpans(events []ApplicationEvent, now time.Time) []StageSpan {
+	spans := []StageSpan{}
+	for _, e := range events {
+		if e.Type != EventCreated && e.Type != EventStatusChange {
+			continue
+		}
+		if n := len(spans); n > 0 {
+			left := e.OccurredAt
+			spans[n-1].LeftAt = &left
+			spans[n-1].Days = days(left.Sub(spans[n-1].EnteredAt))
+		}
+		spans = append(spans, StageSpan{Status: ApplicationStatus(e.To), EnteredAt: e.OccurredAt})
+	}
+	if n := len(spans); n > 0 {
+		spans[n-1].Days = days(now.Sub(spans[n-1].EnteredAt))
+	}
+	return spans
+}
+
+func days(d time.Duration) float64 {
+	if d < 0 {
+		return 0
+	}
+	return float64(int(d.Hours()/24*10)) / 10
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStageSpans(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 9, 0, 0, 0, time.UTC) }
+	ptr := func(t time.Time) *time.Time { return &t }
+	events := []ApplicationEvent{
+		{Type: EventCreated, To: "Applied", OccurredAt: day(1)},
+		{Type: EventNote, Note: "Sent a thank-you", OccurredAt: day(2)},
+		{Type: EventStatusChange, From: "Applied", To: "Interviewing", OccurredAt: day(4)},
+		{Type: EventFieldChange, Field: "role", From: "Dev", To: "Senior Dev", OccurredAt: day(5)},
+		{Type: EventStatusChange, From: "Interviewing", To: "Offer", OccurredAt: day(11).Add(12 * time.Hour)},
+	}
+
+	got := StageSpans(events, day(14))
+	want := []StageSpan{
+		{Status: "Applied", EnteredAt: day(1), LeftAt: ptr(day(4)), Days: 3},
+		{Status: "Interviewing", EnteredAt: day(4), LeftAt: ptr(day(11).Add(12 * time.Hour)), Days: 7.5},
+		{Status: "Offer", EnteredAt: day(11).Add(12 * time.Hour), Days: 2.5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StageSpans() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestStageSpansEdges(t *testing.T) {
+	created := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		events []ApplicationEvent
+		now    time.Time
+		want   []StageSpan
+	}{
+		{"no events", nil, created, []StageSpan{}},
+		{"only manual events", []ApplicationEvent{{Type: EventCall, OccurredAt: created}}, created, []StageSpan{}},
+		{
+			"clock behind the last change",
+			[]ApplicationEvent{{Type: EventCreated, To: "Applied", OccurredAt: created}},
+			created.Add(-time.Hour),
+			[]StageSpan{{Status: "Applied", EnteredAt: created, Days: 0}},
+		},
+		{
+			"partial days round down",
+			[]ApplicationEvent{{Type: EventCreated, To: "Applied", OccurredAt: created}},
+			created.Add(35 * time.Hour),
+			[]StageSpan{{Status: "Applied", EnteredAt: created, Days: 1.4}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StageSpans(tt.events, tt.now); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StageSpans() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// InterviewType is the kind of an interview round.
+type InterviewType string
+
+const (
+	InterviewPhoneScreen InterviewType = "phone_screen"
+	InterviewTechnical   InterviewType = "technical"
+	InterviewBehavioral  InterviewType = "behavioral"
+	InterviewOnsite      InterviewType = "onsite"
+	InterviewPanel       InterviewType = "panel"
+	InterviewFinal       InterviewType = "final"
+	InterviewOther       InterviewType = "other"
+)
+
+// Valid reports whether t is one of the known interview types.
+func (t InterviewType) Valid() bool {
+	switch t {
+	case InterviewPhoneScreen, InterviewTechnical, InterviewBehavioral, InterviewOnsite,
+		InterviewPanel, InterviewFinal, InterviewOther:
+		return true
+	}
+	return false
+}
+
+// InterviewOutcome is the result of an interview round.
+type InterviewOutcome string
+
+const (
+	OutcomePending   InterviewOutcome = "pending"
+	OutcomePassed    InterviewOutcome = "passed"
+	OutcomeFailed    InterviewOutcome = "failed"
+	OutcomeCancelled InterviewOutcome = "cancelled"
+)
+
+// Valid reports whether o is one of the known outcomes.
+func (o InterviewOutcome) Valid() bool {
+	switch o {
+	case OutcomePending, OutcomePassed, OutcomeFailed, OutcomeCancelled:
+		return true
+	}
+	return false
+}
+
+// Interview is a scheduled interview round of an application.
+type Interview struct {
+	ID            string           `json:"id"`
+	ApplicationID string           `json:"applicationId"`
+	Company       string           `json:"company"` // From the application
+	Role          string           `json:"role"`    // From the application
+	Type          InterviewType    `json:"type"`
+	StartsAt      time.Time        `json:"startsAt"`
+	EndsAt        time.Time        `json:"endsAt"`
+	Timezone      string           `json:"timezone"` // IANA name, e.g. "Europe/Madrid"
+	Location      string           `json:"location"`
+	VideoLink     string           `json:"videoLink"`
+	Interviewers  []string         `json:"interviewers"`
+	Outcome       InterviewOutcome `json:"outcome"`
+	Notes         string           `json:"notes"`
+	CreatedAt     time.Time        `json:"createdAt"`
+	UpdatedAt     time.Time        `json:"updatedAt"`
+}
+
+// InterviewRequest is the payload for creating or updating an interview.
+type InterviewRequest struct {
+	Type         InterviewType    `json:"type"`
+	StartsAt     time.Time        `json:"startsAt"`
+	EndsAt       time.Time        `json:"endsAt"`
+	Timezone     string           `json:"timezone"`
+	Location     string           `json:"location"`
+	VideoLink    string           `json:"videoLink"`
+	Interviewers []string         `json:"interviewers"`
+	Outcome      InterviewOutcome `json:"outcome"` // Defaults to pending
+	Notes        string           `json:"notes"`
+}
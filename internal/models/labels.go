@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// LabelPack is a named set of SectionLabels for one locale. Built-in packs
+// ship with the server; the rest are stored per user.
+type LabelPack struct {
+	ID        string        `json:"id"`
+	Name      string        `json:"name"`
+	Locale    string        `json:"locale"`
+	Labels    SectionLabels `json:"labels"`
+	BuiltIn   bool          `json:"builtIn"`
+	CreatedAt time.Time     `json:"createdAt,omitzero"`
+	UpdatedAt time.Time     `json:"updatedAt,omitzero"`
+}
+
+// LabelPackRequest is the payload for creating or updating a user label pack.
+type LabelPackRequest struct {
+	Name   string        `json:"name"`
+	Locale string        `json:"locale"`
+	Labels SectionLabels `json:"labels"`
+}
+
+// ApplyLabelPackRequest is the payload for applying a label pack to a CV.
+// Locale selects which translation of the CV receives the labels; when empty
+// the pack's own locale is used if the CV has it, else the default content.
+type ApplyLabelPackRequest struct {
+	PackID string  `json:"packId"`
+	Locale *string `json:"locale"`
+}
+
+// BuiltinLabelPackPrefix prefixes the IDs of the built-in label packs.
+const BuiltinLabelPackPrefix = "builtin-"
+
+var builtinLabels = []struct {
+	locale, name string
+	labels       SectionLabels
+}{
+	{"en", "English", SectionLabels{"Summary", "Professional Experience", "Education", "Skills", "Languages", "Certifications", "Present"}},
+	{"es", "Español", SectionLabels{"Resumen", "Experiencia profesional", "Formación", "Habilidades", "Idiomas", "Certificaciones", "Actualidad"}},
+	{"ca", "Català", SectionLabels{"Resum", "Experiència professional", "Formació", "Habilitats", "Idiomes", "Certificacions", "Actualitat"}},
+	{"de", "Deutsch", SectionLabels{"Profil", "Berufserfahrung", "Ausbildung", "Kenntnisse", "Sprachen", "Zertifizierungen", "heute"}},
+	{"fr", "Français", SectionLabels{"Profil", "Expérience professionnelle", "Formation", "Compétences", "Langues", "Certifications", "Aujourd'hui"}},
+	{"it", "Italiano", SectionLabels{"Profilo", "Esperienza professionale", "Istruzione", "Competenze", "Lingue", "Certificazioni", "Presente"}},
+	{"pt", "Português", SectionLabels{"Resumo", "Experiência profissional", "Formação académica", "Competências", "Idiomas", "Certificações", "Atual"}},
+	{"nl", "Nederlands", SectionLabels{"Profiel", "Werkervaring", "Opleiding", "Vaardigheden", "Talen", "Certificeringen", "Heden"}},
+	{"pl", "Polski", SectionLabels{"Podsumowanie", "Doświadczenie zawodowe", "Wykształcenie", "Umiejętności", "Języki", "Certyfikaty", "Obecnie"}},
+	{"sv", "Svenska", SectionLabels{"Sammanfattning", "Arbetslivserfarenhet", "Utbildning", "Kompetenser", "Språk", "Certifieringar", "Nuvarande"}},
+	{"da", "Dansk", SectionLabels{"Profil", "Erhvervserfaring", "Uddannelse", "Kompetencer", "Sprog", "Certificeringer", "Nu"}},
+	{"nb", "Norsk bokmål", SectionLabels{"Sammendrag", "Arbeidserfaring", "Utdanning", "Ferdigheter", "Språk", "Sertifiseringer", "Nå"}},
+	{"fi", "Suomi", SectionLabels{"Tiivistelmä", "Työkokemus", "Koulutus", "Taidot", "Kielitaito", "Sertifikaatit", "Nykyään"}},
+}
+
+// BuiltinLabelPacks returns the label packs shipped with the server.
+func BuiltinLabelPacks() []LabelPack {
+	packs := make([]LabelPack, 0, len(builtinLabels))
+	for _, b := range builtinLabels {
+		packs = append(packs, LabelPack{
+			ID:      BuiltinLabelPackPrefix + b.locale,
+			Name:    b.name,
+			Locale:  b.locale,
+			Labels:  b.labels,
+			BuiltIn: true,
+		})
+	}
+	return packs
+}
+
+// BuiltinLabelPack returns the built-in pack with the given ID, or nil.
+func BuiltinLabelPack(id string) *LabelPack {
+	for _, p := range BuiltinLabelPacks() {
+		if p.ID == id {
+			return &p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// LibraryKind is the type of content held by a LibraryItem.
+type LibraryKind string
+
+const (
+	LibraryExperience LibraryKind = "experience"
+	LibraryBullet     LibraryKind = "bullet"
+	LibrarySummary    LibraryKind = "summary"
+	LibrarySkills     LibraryKind = "skills"
+)
+
+// Valid reports whether k is one of the known library kinds.
+func (k LibraryKind) Valid() bool {
+	switch k {
+	case LibraryExperience, LibraryBullet, LibrarySummary, LibrarySkills:
+		return true
+	}
+	return false
+}
+
+// LibraryItem is a reusable block of CV content. CVs reference items by ID
+// through the Ref fields of their entries; the content is copied into the
+// CV whenever it is saved and whenever the item changes.
+type LibraryItem struct {
+	ID        string         `json:"id"`
+	Kind      LibraryKind    `json:"kind"`
+	Name      string         `json:"name"`
+	Content   LibraryContent `json:"content"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+// LibraryContent holds the content of a LibraryItem. Only the field that
+// matches the item's kind is used: Experience, Text (bullets and
+// summaries) or Skills.
+type LibraryContent struct {
+	Experience *Experience `json:"experience,omitempty"`
+	Text       string      `json:"text,omitempty"`
+	Skills     *SkillGroup `json:"skills,omitempty"`
+}
+
+// LibraryItemRequest is the payload for creating or updating a library item.
+type LibraryItemRequest struct {
+	Kind    LibraryKind    `json:"kind"`
+	Name    string         `json:"name"`
+	Content LibraryContent `json:"content"`
+}
+
+// LibraryUsage is one place a library item is used.
+type LibraryUsage struct {
+	CVID    string `json:"cvId"`
+	CVTitle string `json:"cvTitle"`
+	Path    string `json:"path"` // JSON pointer into the CV data
+}
+
+// LibraryRefs returns every library reference in data, keyed by item ID,
+// with the JSON pointer of each place it is used.
+func LibraryRefs(data CVData) map[string][]string {
+	refs := map[string][]string{}
+	if data.SummaryRef != "" {
+		refs[data.SummaryRef] = append(refs[data.SummaryRef], "/summary")
+	}
+	for i, exp := range data.Experience {
+		if exp.Ref != "" {
+			refs[exp.Ref] = append(refs[exp.Ref], fmt.Sprintf("/experience/%d", i))
+		}
+		for j, b := range exp.Bullets {
+			if b.Ref != "" {
+				refs[b.Ref] = append(refs[b.Ref], fmt.Sprintf("/experience/%d/bullets/%d", i, j))
+			}
+		}
+	}
+	for i, g := range data.Skills {
+		if g.Ref != "" {
+			refs[g.Ref] = append(refs[g.Ref], fmt.Sprintf("/skills/%d", i))
+		}
+	}
+	return refs
+}
+
+// ApplyLibrary copies the content of the referenced library items into data.
+// It returns the JSON pointers of references that are not in items or point
+// at an item of the wrong kind.
+func ApplyLibrary(data *CVData, items map[string]LibraryItem) []string {
+	var missing []string
+	resolve := func(ref string, kind LibraryKind, path string) (LibraryItem, bool) {
+		item, ok := items[ref]
+		if !ok || item.Kind != kind {
+			missing = append(missing, path)
+			return item, false
+		}
+		return item, true
+	}
+
+	if data.SummaryRef != "" {
+		if item, ok := resolve(data.SummaryRef, LibrarySummary, "/summaryRef"); ok {
+			data.Summary = item.Content.Text
+		}
+	}
+	for i := range data.Experience {
+		exp := &data.Experience[i]
+		if exp.Ref != "" {
+			if item, ok := resolve(exp.Ref, LibraryExperience, fmt.Sprintf("/experience/%d/ref", i)); ok && item.Content.Experience != nil {
+				ref := exp.Ref
+				*exp = *item.Content.Experience
+				exp.Ref = ref
+				exp.Bullets = append([]Bullet(nil), exp.Bullets...)
+			}
+		}
+		for j := range exp.Bullets {
+			b := &exp.Bullets[j]
+			if b.Ref == "" {
+				continue
+			}
+			if item, ok := resolve(b.Ref, LibraryBullet, fmt.Sprintf("/experience/%d/bullets/%d/ref", i, j)); ok {
+				b.Text = item.Content.Text
+			}
+		}
+	}
+	for i := range data.Skills {
+		g := &data.Skills[i]
+		if g.Ref == "" {
+			continue
+		}
+		if item, ok := resolve(g.Ref, LibrarySkills, fmt.Sprintf("/skills/%d/ref", i)); ok && item.Content.Skills != nil {
+			ref := g.Ref
+			*g = *item.Content.Skills
+			g.Ref = ref
+			g.Items = append([]Skill(nil), g.Items...)
+		}
+	}
+	return missing
+}
+
+// DetachLibrary removes every reference to the item with the given ID,
+// keeping the copied content in place.
+func DetachLibrary(data *CVData, id string) {
+	if data.SummaryRef == id {
+		data.SummaryRef = ""
+	}
+	for i := range data.Experience {
+		if data.Experience[i].Ref == id {
+			data.Experience[i].Ref = ""
+		}
+		for j := range data.Experience[i].Bullets {
+			if data.Experience[i].Bullets[j].Ref == id {
+				data.Experience[i].Bullets[j].Ref = ""
+			}
+		}
+	}
+	for i := range data.Skills {
+		if data.Skills[i].Ref == id {
+			data.Skills[i].Ref = ""
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// MaxListLimit bounds the page size of list endpoints.
+const MaxListLimit = 200
+
+// ListOptions order and page a list. Without a limit the whole list is
+// returned.
+type ListOptions struct {
+	Sort   string // Field to order by; each list has its own, and a default
+	Order  string // "asc" or "desc"; the default depends on the field
+	Limit  int
+	Cursor string // Where the previous page ended
+}
+
+// ApplicationFilter selects applications. All conditions must hold.
+type ApplicationFilter struct {
+	ListOptions
+	Statuses  []ApplicationStatus // Any of these
+	CompanyID string
+	Company   string     // Part of the company name, case-insensitively
+	From      *time.Time // First day of the application date, inclusive
+	To        *time.Time // Last day of the application date, inclusive
+	CVID      string
+	Query     string // Words that must each appear in the role or notes
+	OwnOnly   bool   // Leave out legacy applications without an owner
+}
+
+// CVFilter selects CVs.
+type CVFilter struct {
+	ListOptions
+	Query string // Words that must each appear in the title
+}
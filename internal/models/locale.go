@@ -0,0 +1,131 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// LocalizedExperience translates the Experience entry at the same index.
+// Empty fields fall back to the default locale.
+type LocalizedExperience struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// LocalizedEducation translates the Education entry at the same index.
+type LocalizedEducation struct {
+	Degree      string `json:"degree,omitempty"`
+	Field       string `json:"field,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// LocalizedContent holds the translatable parts of a CV for one locale.
+// Entries are matched to the base content by index; anything left empty
+// falls back to the default locale.
+type LocalizedContent struct {
+	Title           string                `json:"title,omitempty"` // PersonalInfo.Title
+	Summary         string                `json:"summary,omitempty"`
+	Experience      []LocalizedExperience `json:"experience,omitempty"`
+	Education       []LocalizedEducation  `json:"education,omitempty"`
+	SkillCategories []string              `json:"skillCategories,omitempty"`
+	Labels          *SectionLabels        `json:"labels,omitempty"`
+}
+
+// ResolveLocale picks the entry of Locales that best matches lang: an exact
+// match first, then one sharing the primary language ("de-AT" -> "de").
+// It returns "" when the default locale should be used.
+func (d CVData) ResolveLocale(lang string) string {
+	lang = normalizeLocale(lang)
+	if lang == "" || lang == normalizeLocale(d.DefaultLocale) {
+		return ""
+	}
+	keys := make([]string, 0, len(d.Locales))
+	for key := range d.Locales {
+		keys = append(keys, key)
+	}
+	// Sorted so that "de" is preferred over "de-CH" when falling back
+	sort.Strings(keys)
+
+	primary, _, _ := strings.Cut(lang, "-")
+	var fallback string
+	for _, key := range keys {
+		k := normalizeLocale(key)
+		if k == lang {
+			return key
+		}
+		if kp, _, _ := strings.Cut(k, "-"); kp == primary && fallback == "" {
+			fallback = key
+		}
+	}
+	return fallback
+}
+
+// Localize returns a copy of the CV content rendered in lang, with
+// untranslated fields taken from the default locale. The result carries no
+// Locales of its own. An unknown lang yields the default locale.
+func (d CVData) Localize(lang string) CVData {
+	key := d.ResolveLocale(lang)
+	out := d
+	out.Locales = nil
+	if key == "" {
+		return out
+	}
+	loc := d.Locales[key]
+	out.DefaultLocale = key
+
+	out.Personal.Title = pick(loc.Title, d.Personal.Title)
+	out.Summary = pick(loc.Summary, d.Summary)
+
+	out.Experience = append([]Experience(nil), d.Experience...)
+	for i := range out.Experience {
+		if i >= len(loc.Experience) {
+			break
+		}
+		out.Experience[i].Title = pick(loc.Experience[i].Title, out.Experience[i].Title)
+		out.Experience[i].Description = pick(loc.Experience[i].Description, out.Experience[i].Description)
+	}
+
+	out.Education = append([]Education(nil), d.Education...)
+	for i := range out.Education {
+		if i >= len(loc.Education) {
+			break
+		}
+		out.Education[i].Degree = pick(loc.Education[i].Degree, out.Education[i].Degree)
+		out.Education[i].Field = pick(loc.Education[i].Field, out.Education[i].Field)
+		out.Education[i].Description = pick(loc.Education[i].Description, out.Education[i].Description)
+	}
+
+	out.Skills = append([]SkillGroup(nil), d.Skills...)
+	for i := range out.Skills {
+		if i >= len(loc.SkillCategories) {
+			break
+		}
+		out.Skills[i].Category = pick(loc.SkillCategories[i], out.Skills[i].Category)
+	}
+
+	if loc.Labels != nil {
+		labels := *loc.Labels
+		if d.Labels != nil {
+			labels.Summary = pick(labels.Summary, d.Labels.Summary)
+			labels.Experience = pick(labels.Experience, d.Labels.Experience)
+			labels.Education = pick(labels.Education, d.Labels.Education)
+			labels.Skills = pick(labels.Skills, d.Labels.Skills)
+			labels.Languages = pick(labels.Languages, d.Labels.Languages)
+			labels.Certifications = pick(labels.Certifications, d.Labels.Certifications)
+			labels.Present = pick(labels.Present, d.Labels.Present)
+		}
+		out.Labels = &labels
+	}
+	return out
+}
+
+func normalizeLocale(s string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), "_", "-"))
+}
+
+func pick(value, fallback string) string {
+	if strings.TrimSpace(value) != "" {
+		return value
+	}
+	return fallback
+}
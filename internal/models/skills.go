@@ -0,0 +1,164 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SkillLevel is an optional proficiency level for a skill.
+type SkillLevel string
+
+const (
+	SkillBeginner     SkillLevel = "beginner"
+	SkillIntermediate SkillLevel = "intermediate"
+	SkillAdvanced     SkillLevel = "advanced"
+	SkillExpert       SkillLevel = "expert"
+)
+
+// Skill is a single item of a SkillGroup. Only Name is required.
+type Skill struct {
+	Name     string     `json:"name"`
+	Level    SkillLevel `json:"level,omitempty"`
+	Years    float64    `json:"years,omitempty"`    // Years of experience
+	LastUsed int        `json:"lastUsed,omitempty"` // Year the skill was last used
+	Tags     []string   `json:"tags,omitempty"`
+}
+
+// UnmarshalJSON accepts both the structured form and the legacy plain string
+// form ("Go") used before skills carried any metadata.
+func (s *Skill) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		*s = Skill{Name: name}
+		return nil
+	}
+	type plain Skill
+	var p plain
+	if err := json.Unmarshal(b, &p); err != nil {
+		return err
+	}
+	*s = Skill(p)
+	return nil
+}
+
+// MarshalJSON writes skills without metadata as plain strings so existing
+// clients that treat items as a list of names keep working.
+func (s Skill) MarshalJSON() ([]byte, error) {
+	type plain Skill
+	if s.Level == "" && s.Years == 0 && s.LastUsed == 0 && len(s.Tags) == 0 {
+		return json.Marshal(s.Name)
+	}
+	return json.Marshal(plain(s))
+}
+
+// DeriveSkillYears fills in Years and LastUsed for skills that do not set
+// them, based on the Experience entries whose title or description mention
+// the skill. Overlapping entries are only counted once. Explicit values are
+// never overwritten.
+func DeriveSkillYears(data *CVData, now time.Time) {
+	for gi := range data.Skills {
+		for si := range data.Skills[gi].Items {
+			skill := &data.Skills[gi].Items[si]
+			if skill.Years > 0 && skill.LastUsed > 0 {
+				continue
+			}
+			months, last := skillUsage(skill.Name, data.Experience, now)
+			if months == 0 {
+				continue
+			}
+			if skill.Years == 0 {
+				skill.Years = math.Round(float64(months)/12*10) / 10
+			}
+			if skill.LastUsed == 0 {
+				skill.LastUsed = last
+			}
+		}
+	}
+}
+
+type monthSpan struct {
+	start, end time.Time // end is exclusive
+}
+
+// skillUsage returns the number of distinct months covered by experience
+// entries mentioning name, and the year of the most recent one.
+func skillUsage(name string, experience []Experience, now time.Time) (int, int) {
+	var spans []monthSpan
+	for _, exp := range experience {
+		if !mentions(exp.Title+"\n"+exp.Description, name) {
+			continue
+		}
+		start, ok := ParseCVDate(exp.StartDate)
+		if !ok {
+			continue
+		}
+		end, ok := ParseCVDate(exp.EndDate)
+		if exp.Current || !ok {
+			end = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		}
+		if end.Before(start) {
+			continue
+		}
+		spans = append(spans, monthSpan{start: start, end: end.AddDate(0, 1, 0)})
+	}
+	if len(spans) == 0 {
+		return 0, 0
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+	merged := []monthSpan{spans[0]}
+	for _, sp := range spans[1:] {
+		cur := &merged[len(merged)-1]
+		if !sp.start.After(cur.end) {
+			if sp.end.After(cur.end) {
+				cur.end = sp.end
+			}
+			continue
+		}
+		merged = append(merged, sp)
+	}
+
+	months := 0
+	for _, sp := range merged {
+		months += monthsBetween(sp.start, sp.end)
+	}
+	last := merged[len(merged)-1].end.AddDate(0, -1, 0).Year()
+	return months, last
+}
+
+func monthsBetween(start, end time.Time) int {
+	return (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+}
+
+// mentions reports whether text contains name as a whole word, ignoring case.
+// Names like "C++" or "Node.js" are matched literally.
+func mentions(text, name string) bool {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return false
+	}
+	text = strings.ToLower(text)
+	for i := 0; ; {
+		j := strings.Index(text[i:], name)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(name)
+		if !isWordByteAt(text, start-1) && !isWordByteAt(text, end) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+func isWordByteAt(s string, i int) bool {
+	if i < 0 || i >= len(s) {
+		return false
+	}
+	r := rune(s[i])
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,192 @@
+package notify
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+// smtpDelivery is a message received by the SMTP stand-in.
+type smtpDelivery struct {
+	from string
+	to   []string
+	data string
+}
+
+// smtpStandIn runs a minimal SMTP server on a local port, without STARTTLS
+// or AUTH, and returns its address and the messages it receives.
+func smtpStandIn(t *testing.T) (host, port string, received <-chan smtpDelivery) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	out := make(chan smtpDelivery, 1)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, out)
+		}
+	}()
+	host, port, _ = net.SplitHostPort(ln.Addr().String())
+	return host, port, out
+}
+
+func serveSMTP(conn net.Conn, out chan<- smtpDelivery) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+	reply("220 localhost ESMTP")
+
+	var d smtpDelivery
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			d.from = strings.Trim(strings.TrimSpace(line)[len("MAIL FROM:"):], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			d.to = append(d.to, strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>"))
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			d.data = data.String()
+			out <- d
+			d = smtpDelivery{}
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	host, port, received := smtpStandIn(t)
+	m := &SMTPMailer{Host: host, Port: port, From: mail.Address{Name: "CV Forge", Address: "noreply@example.com"}}
+	msg := Message{
+		To:      mail.Address{Name: "Zoë Ann", Address: "zoe@example.com"},
+		Subject: "Follow up: Développeur at Ácme",
+		Body:    "Hi Zoë,\n\nIt's time to follow up.\n.\nA line long enough to be wrapped by the quoted-printable encoder, well past seventy-six characters.\n",
+	}
+	if err := m.Send(msg); err != nil {
+		t.Fatal(err)
+	}
+	d := <-received
+
+	if d.from != "noreply@example.com" {
+		t.Errorf("MAIL FROM = %q", d.from)
+	}
+	if len(d.to) != 1 || d.to[0] != "zoe@example.com" {
+		t.Errorf("RCPT TO = %q", d.to)
+	}
+	for _, line := range strings.SplitAfter(d.data, "\n") {
+		if line != "" && !strings.HasSuffix(line, "\r\n") {
+			t.Fatalf("line not ended by CRLF: %q", line)
+		}
+		if len(line) > 78 {
+			t.Errorf("line longer than 78 characters: %q", line)
+		}
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(d.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := parsed.Header
+	subject, err := new(mime.WordDecoder).DecodeHeader(h.Get("Subject"))
+	if err != nil || subject != msg.Subject {
+		t.Errorf("Subject = %q (%v), want %q", subject, err, msg.Subject)
+	}
+	if to, err := h.AddressList("To"); err != nil || len(to) != 1 || *to[0] != msg.To {
+		t.Errorf("To = %v (%v), want %v", to, err, msg.To)
+	}
+	if from, err := h.AddressList("From"); err != nil || len(from) != 1 || *from[0] != m.From {
+		t.Errorf("From = %v (%v), want %v", from, err, m.From)
+	}
+	for _, name := range []string{"Date", "Message-ID"} {
+		if h.Get(name) == "" {
+			t.Errorf("missing %s header", name)
+		}
+	}
+	if got := h.Get("Content-Transfer-Encoding"); got != "quoted-printable" {
+		t.Errorf("Content-Transfer-Encoding = %q", got)
+	}
+
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := strings.ReplaceAll(msg.Body, "\n", "\r\n"); string(body) != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+}
+
+func TestSMTPMailerSendRefused(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	ln.Close()
+
+	m := &SMTPMailer{Host: host, Port: port, From: mail.Address{Address: "noreply@example.com"}}
+	if err := m.Send(Message{To: mail.Address{Address: "ann@example.com"}, Subject: "Hi"}); err == nil {
+		t.Error("Send succeeded with no server listening")
+	}
+}
+
+func TestNewSMTPMailerFromEnv(t *testing.T) {
+	t.Setenv("SMTP_HOST", "")
+	m, err := NewSMTPMailerFromEnv()
+	if m != nil || err != nil {
+		t.Errorf("without SMTP_HOST got %v, %v; want email disabled", m, err)
+	}
+
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	t.Setenv("SMTP_PORT", "")
+	t.Setenv("SMTP_FROM", "CV Forge <noreply@example.com>")
+	m, err = NewSMTPMailerFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Port != "587" {
+		t.Errorf("Port = %q, want the default 587", m.Port)
+	}
+	if m.From.Name != "CV Forge" || m.From.Address != "noreply@example.com" {
+		t.Errorf("From = %v", m.From)
+	}
+
+	t.Setenv("SMTP_FROM", "not an address")
+	if _, err := NewSMTPMailerFromEnv(); err == nil {
+		t.Error("invalid SMTP_FROM accepted")
+	}
+}
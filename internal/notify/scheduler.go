@@ -0,0 +1,206 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/db"
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// Scheduler periodically sends follow-up reminders and the weekly digest.
+type Scheduler struct {
+	db     *db.DB
+	mailer Mailer
+
+	// Interval between runs.
+	Interval time.Duration
+	// StaleAfter is how long an open application may go without activity
+	// before it shows up in the digest.
+	StaleAfter time.Duration
+	// MaxReminderAge skips follow-ups older than this, so enabling email
+	// does not send a burst of reminders for long-past dates.
+	MaxReminderAge time.Duration
+	// AppURL, if set, is linked from the emails.
+	AppURL string
+}
+
+// NewScheduler returns a scheduler with default settings.
+func NewScheduler(database *db.DB, mailer Mailer) *Scheduler {
+	return &Scheduler{
+		db:             database,
+		mailer:         mailer,
+		Interval:       15 * time.Minute,
+		StaleAfter:     models.DefaultStaleAfter,
+		MaxReminderAge: 7 * 24 * time.Hour,
+	}
+}
+
+// Run sends notifications until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		if err := s.RunOnce(time.Now().UTC()); err != nil {
+			log.Printf("notify: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce sends the notifications due at now.
+func (s *Scheduler) RunOnce(now time.Time) error {
+	users, err := s.db.ListUsers()
+	if err != nil {
+		return fmt.Errorf("list users: %w", err)
+	}
+	for _, u := range users {
+		if err := s.notifyUser(u, now); err != nil {
+			log.Printf("notify: user %s: %v", u.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) notifyUser(u models.User, now time.Time) error {
+	if u.Email == "" {
+		return nil
+	}
+	// Applications without an owner are not the user's to be told about
+	apps, _, err := s.db.QueryApplications(u.ID, models.ApplicationFilter{OwnOnly: true})
+	if err != nil {
+		return err
+	}
+	stages, err := s.db.GetPipeline(u.ID)
+	if err != nil {
+		return err
+	}
+
+	var open []models.Application
+	for _, app := range apps {
+		if stage := models.FindStage(stages, app.Status); stage == nil || !stage.Terminal {
+			open = append(open, app)
+		}
+	}
+
+	for _, app := range open {
+		if app.FollowUpAt == nil || app.FollowUpAt.After(now) || now.Sub(*app.FollowUpAt) > s.MaxReminderAge {
+			continue
+		}
+		key := fmt.Sprintf("followup:%s:%d", app.ID, app.FollowUpAt.Unix())
+		if err := s.send(key, followUpMessage(u, app, s.AppURL)); err != nil {
+			return err
+		}
+	}
+
+	// The digest goes out once per ISO week, from Monday 08:00 UTC
+	if now.Weekday() == time.Monday && now.Hour() < 8 {
+		return nil
+	}
+	last, err := s.db.LastActivity(u.ID)
+	if err != nil {
+		return err
+	}
+	var stale []staleApplication
+	for _, app := range open {
+		at := app.UpdatedAt
+		if t := last[app.ID]; t.After(at) {
+			at = t
+		}
+		if now.Sub(at) >= s.StaleAfter {
+			stale = append(stale, staleApplication{app, at})
+		}
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+	year, week := now.ISOWeek()
+	key := fmt.Sprintf("digest:%s:%d-W%02d", u.ID, year, week)
+	return s.send(key, digestMessage(u, stale, now, s.AppURL))
+}
+
+// send delivers msg unless the notification key was already sent.
+func (s *Scheduler) send(key string, msg Message) error {
+	claimed, err := s.db.ClaimNotification(key)
+	if err != nil || !claimed {
+		return err
+	}
+	if err := s.mailer.Send(msg); err != nil {
+		if rerr := s.db.ReleaseNotification(key); rerr != nil {
+			log.Printf("notify: release %s: %v", key, rerr)
+		}
+		return fmt.Errorf("send %s: %w", key, err)
+	}
+	return nil
+}
+
+type staleApplication struct {
+	models.Application
+	LastActivity time.Time
+}
+
+func followUpMessage(u models.User, app models.Application, appURL string) Message {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Hi %s,\n\n", firstName(u))
+	fmt.Fprintf(&b, "It's time to follow up on your application for %s at %s.\n\n", app.Role, app.Company)
+	fmt.Fprintf(&b, "Status: %s\n", app.Status)
+	if !app.Date.IsZero() {
+		fmt.Fprintf(&b, "Applied: %s\n", app.Date.Format("2 Jan 2006"))
+	}
+	if app.URL != "" {
+		fmt.Fprintf(&b, "Posting: %s\n", app.URL)
+	}
+	writeFooter(&b, appURL)
+	return Message{
+		To:      mail.Address{Name: u.Name, Address: u.Email},
+		Subject: fmt.Sprintf("Follow up: %s at %s", app.Role, app.Company),
+		Body:    b.String(),
+	}
+}
+
+func digestMessage(u models.User, stale []staleApplication, now time.Time, appURL string) Message {
+	sort.Slice(stale, func(i, j int) bool { return stale[i].LastActivity.Before(stale[j].LastActivity) })
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Hi %s,\n\n", firstName(u))
+	fmt.Fprintf(&b, "These applications have had no activity for a while:\n\n")
+	for _, app := range stale {
+		days := int(now.Sub(app.LastActivity).Hours() / 24)
+		fmt.Fprintf(&b, "- %s at %s (%s, %d days)\n", app.Role, app.Company, app.Status, days)
+	}
+	fmt.Fprintf(&b, "\nChase them, or move them to a closing stage to keep your pipeline current.\n")
+	writeFooter(&b, appURL)
+
+	subject := "1 application needs attention"
+	if len(stale) > 1 {
+		subject = fmt.Sprintf("%d applications need attention", len(stale))
+	}
+	return Message{
+		To:      mail.Address{Name: u.Name, Address: u.Email},
+		Subject: subject,
+		Body:    b.String(),
+	}
+}
+
+func writeFooter(b *strings.Builder, appURL string) {
+	if appURL != "" {
+		fmt.Fprintf(b, "\nOpen CV Forge: %s\n", strings.TrimRight(appURL, "/"))
+	}
+	b.WriteString("\n-- \nCV Forge\n")
+}
+
+func firstName(u models.User) string {
+	if name, _, _ := strings.Cut(strings.TrimSpace(u.Name), " "); name != "" {
+		return name
+	}
+	return "there"
+}
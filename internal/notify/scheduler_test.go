@@ -0,0 +1,211 @@
+package notify
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/db"
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// fakeMailer records the messages it is given, or fails with err.
+type fakeMailer struct {
+	sent []Message
+	err  error
+}
+
+func (m *fakeMailer) Send(msg Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func newTestScheduler(t *testing.T) (*Scheduler, *fakeMailer, *db.DB, *models.User) {
+	t.Helper()
+	d, err := db.New(filepath.Join(t.TempDir(), "data.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { d.Close() })
+	u, err := d.CreateOrUpdateUser("ann@example.com", "Ann Lee", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &fakeMailer{}
+	s := NewScheduler(d, m)
+	// No digest unless a test asks for one
+	s.StaleAfter = 365 * 24 * time.Hour
+	return s, m, d, u
+}
+
+func createApplication(t *testing.T, d *db.DB, userID string, req models.CreateApplicationRequest) {
+	t.Helper()
+	if req.Role == "" {
+		req.Role = "Developer"
+	}
+	if req.Status == "" {
+		req.Status = "Applied"
+	}
+	if _, err := d.CreateApplication(userID, req); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runOnce(t *testing.T, s *Scheduler, now time.Time) {
+	t.Helper()
+	if err := s.RunOnce(now); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFollowUpSentOnce(t *testing.T) {
+	s, m, d, u := newTestScheduler(t)
+	now := time.Now().UTC()
+	due := now.Add(-time.Hour)
+	createApplication(t, d, u.ID, models.CreateApplicationRequest{Company: "Acme", FollowUpAt: &due})
+
+	runOnce(t, s, now)
+	runOnce(t, s, now.Add(time.Hour))
+	if len(m.sent) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(m.sent))
+	}
+	msg := m.sent[0]
+	if msg.To.Address != u.Email {
+		t.Errorf("To = %q, want %q", msg.To.Address, u.Email)
+	}
+	if want := "Follow up: Developer at Acme"; msg.Subject != want {
+		t.Errorf("Subject = %q, want %q", msg.Subject, want)
+	}
+	if !strings.HasPrefix(msg.Body, "Hi Ann,") {
+		t.Errorf("Body does not greet the user by first name:\n%s", msg.Body)
+	}
+}
+
+func TestFollowUpRescheduled(t *testing.T) {
+	s, m, d, u := newTestScheduler(t)
+	now := time.Now().UTC()
+	due := now.Add(-time.Hour)
+	createApplication(t, d, u.ID, models.CreateApplicationRequest{Company: "Acme", FollowUpAt: &due})
+	runOnce(t, s, now)
+
+	apps, err := d.ListApplications(u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := apps[0]
+	later := now.Add(48 * time.Hour)
+	_, err = d.UpdateApplication(app.ID, u.ID, models.UpdateApplicationRequest{
+		CompanyID: app.CompanyID, Company: app.Company, Role: app.Role, Status: app.Status, Date: app.Date, FollowUpAt: &later,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	runOnce(t, s, now.Add(24*time.Hour))
+	runOnce(t, s, now.Add(49*time.Hour))
+	if len(m.sent) != 2 {
+		t.Fatalf("sent %d messages, want one per follow-up date", len(m.sent))
+	}
+}
+
+func TestFollowUpSkipped(t *testing.T) {
+	now := time.Now().UTC()
+	future := now.Add(time.Hour)
+	tooOld := now.Add(-8 * 24 * time.Hour)
+	due := now.Add(-time.Hour)
+	tests := []struct {
+		name string
+		req  models.CreateApplicationRequest
+	}{
+		{"no follow-up", models.CreateApplicationRequest{Company: "Acme"}},
+		{"not yet due", models.CreateApplicationRequest{Company: "Acme", FollowUpAt: &future}},
+		{"older than MaxReminderAge", models.CreateApplicationRequest{Company: "Acme", FollowUpAt: &tooOld}},
+		{"closed", models.CreateApplicationRequest{Company: "Acme", Status: "Rejected", FollowUpAt: &due}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m, d, u := newTestScheduler(t)
+			createApplication(t, d, u.ID, tt.req)
+			runOnce(t, s, now)
+			if len(m.sent) != 0 {
+				t.Errorf("sent %q, want nothing", m.sent[0].Subject)
+			}
+		})
+	}
+}
+
+func TestFailedSendRetried(t *testing.T) {
+	s, m, d, u := newTestScheduler(t)
+	now := time.Now().UTC()
+	due := now.Add(-time.Hour)
+	createApplication(t, d, u.ID, models.CreateApplicationRequest{Company: "Acme", FollowUpAt: &due})
+
+	m.err = errors.New("connection refused")
+	runOnce(t, s, now)
+	m.err = nil
+	runOnce(t, s, now.Add(15*time.Minute))
+	runOnce(t, s, now.Add(30*time.Minute))
+	if len(m.sent) != 1 {
+		t.Fatalf("sent %d messages, want 1 after the failed attempt", len(m.sent))
+	}
+}
+
+func TestDigest(t *testing.T) {
+	s, m, d, u := newTestScheduler(t)
+	s.StaleAfter = 14 * 24 * time.Hour
+	createApplication(t, d, u.ID, models.CreateApplicationRequest{Company: "Acme"})
+	createApplication(t, d, u.ID, models.CreateApplicationRequest{Company: "Initech", Status: "Rejected"})
+
+	// A Monday by which the open application has gone stale
+	monday := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 21)
+	for monday.Weekday() != time.Monday {
+		monday = monday.AddDate(0, 0, 1)
+	}
+
+	runOnce(t, s, monday.Add(7*time.Hour+59*time.Minute))
+	if len(m.sent) != 0 {
+		t.Fatalf("digest sent before 08:00 on Monday")
+	}
+	runOnce(t, s, monday.Add(8*time.Hour))
+	if len(m.sent) != 1 {
+		t.Fatalf("sent %d messages at 08:00 on Monday, want the digest", len(m.sent))
+	}
+	if want := "1 application needs attention"; m.sent[0].Subject != want {
+		t.Errorf("Subject = %q, want %q", m.sent[0].Subject, want)
+	}
+	if !strings.Contains(m.sent[0].Body, "Developer at Acme (Applied") {
+		t.Errorf("digest does not list the stale application:\n%s", m.sent[0].Body)
+	}
+	if strings.Contains(m.sent[0].Body, "Initech") {
+		t.Errorf("digest lists a closed application:\n%s", m.sent[0].Body)
+	}
+
+	// Once per week, then again the next week
+	runOnce(t, s, monday.AddDate(0, 0, 2).Add(9*time.Hour))
+	if len(m.sent) != 1 {
+		t.Fatalf("digest sent twice in a week")
+	}
+	runOnce(t, s, monday.AddDate(0, 0, 7).Add(8*time.Hour))
+	if len(m.sent) != 2 {
+		t.Fatalf("sent %d messages, want a digest the next week", len(m.sent))
+	}
+}
+
+func TestDigestNothingStale(t *testing.T) {
+	s, m, d, u := newTestScheduler(t)
+	s.StaleAfter = 60 * 24 * time.Hour
+	createApplication(t, d, u.ID, models.CreateApplicationRequest{Company: "Acme"})
+
+	monday := time.Now().UTC().Truncate(24 * time.Hour)
+	for monday.Weekday() != time.Monday {
+		monday = monday.AddDate(0, 0, 1)
+	}
+	runOnce(t, s, monday.Add(9*time.Hour))
+	if len(m.sent) != 0 {
+		t.Errorf("sent %q with nothing stale", m.sent[0].Subject)
+	}
+}
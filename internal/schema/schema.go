@@ -0,0 +1,137 @@
+// Package schema versions the CVData JSON stored in cvs and cv_versions and
+// in exported files, and upgrades older documents when they are read.
+//
+// To change the shape of CVData in a way old documents cannot decode into,
+// bump Current and append a migration that rewrites a version Current-1
+// document into the new shape.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Current is the schema version written by this build.
+const Current = 2
+
+// migrations[i] upgrades a document from version i+1 to version i+2.
+var migrations = []func(doc map[string]any) error{
+	structuredSkills, // 1 -> 2
+}
+
+// Upgrade migrates raw CVData JSON to the Current version. Documents without
+// a schemaVersion predate versioning and are treated as version 1.
+func Upgrade(raw []byte) ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return raw, nil
+	}
+	upgraded, err := upgradeDoc(doc, 1)
+	if err != nil {
+		return nil, err
+	}
+	if !upgraded {
+		return raw, nil
+	}
+	return json.Marshal(doc)
+}
+
+// UpgradeExport migrates a CVExport document: its data and the data of every
+// version it carries. CV data without its own schemaVersion inherits the
+// export's, or 1 if the export has none either.
+func UpgradeExport(raw []byte) ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return raw, nil
+	}
+	fallback, err := version(doc, 1)
+	if err != nil {
+		return nil, err
+	}
+	if fallback > Current {
+		return nil, fmt.Errorf("export schema version %d is newer than supported version %d", fallback, Current)
+	}
+
+	if data, ok := doc["data"].(map[string]any); ok {
+		if _, err := upgradeDoc(data, fallback); err != nil {
+			return nil, err
+		}
+	}
+	if versions, ok := doc["versions"].([]any); ok {
+		for i, v := range versions {
+			vm, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+			data, ok := vm["data"].(map[string]any)
+			if !ok {
+				continue
+			}
+			if _, err := upgradeDoc(data, fallback); err != nil {
+				return nil, fmt.Errorf("version %d: %w", i, err)
+			}
+		}
+	}
+	doc["schemaVersion"] = Current
+	return json.Marshal(doc)
+}
+
+// upgradeDoc runs the migrations doc needs in place and reports whether any ran.
+func upgradeDoc(doc map[string]any, fallback int) (bool, error) {
+	v, err := version(doc, fallback)
+	if err != nil {
+		return false, err
+	}
+	if v > Current {
+		return false, fmt.Errorf("schema version %d is newer than supported version %d", v, Current)
+	}
+	if v == Current {
+		return false, nil
+	}
+	for ; v < Current; v++ {
+		if err := migrations[v-1](doc); err != nil {
+			return false, fmt.Errorf("migrate schema %d to %d: %w", v, v+1, err)
+		}
+	}
+	doc["schemaVersion"] = Current
+	return true, nil
+}
+
+func version(doc map[string]any, fallback int) (int, error) {
+	raw, ok := doc["schemaVersion"]
+	if !ok || raw == nil {
+		return fallback, nil
+	}
+	f, ok := raw.(float64)
+	if !ok || f < 1 || f != float64(int(f)) {
+		return 0, fmt.Errorf("invalid schema version %v", raw)
+	}
+	return int(f), nil
+}
+
+// --- Migrations ---
+
+// structuredSkills turns the plain string skill items of version 1 into
+// skill objects.
+func structuredSkills(doc map[string]any) error {
+	groups, _ := doc["skills"].([]any)
+	for _, g := range groups {
+		group, ok := g.(map[string]any)
+		if !ok {
+			continue
+		}
+		items, _ := group["items"].([]any)
+		for i, item := range items {
+			if name, ok := item.(string); ok {
+				items[i] = map[string]any{"name": name}
+			}
+		}
+	}
+	return nil
+}
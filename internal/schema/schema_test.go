@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func decode(t *testing.T, raw []byte) map[string]any {
+	t.Helper()
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatalf("decode %s: %v", raw, err)
+	}
+	return doc
+}
+
+func TestUpgrade(t *testing.T) {
+	v1 := `{"name": "Ann Lee", "skills": [
+		{"category": "Languages", "items": ["Go", "SQL"]},
+		{"category": "Tools", "items": ["Docker", {"name": "Git", "level": "expert"}]}
+	]}`
+	want := `{"schemaVersion": 2, "name": "Ann Lee", "skills": [
+		{"category": "Languages", "items": [{"name": "Go"}, {"name": "SQL"}]},
+		{"category": "Tools", "items": [{"name": "Docker"}, {"name": "Git", "level": "expert"}]}
+	]}`
+	got, err := Upgrade([]byte(v1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decode(t, got), decode(t, []byte(want))) {
+		t.Errorf("Upgrade() = %s, want %s", got, want)
+	}
+
+	// A current document is returned as it is
+	current := `{"schemaVersion": 2, "skills": [{"category": "Languages", "items": [{"name": "Go"}]}]}`
+	got, err = Upgrade([]byte(current))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != current {
+		t.Errorf("Upgrade() of a current document = %s, want it unchanged", got)
+	}
+}
+
+func TestUpgradeErrors(t *testing.T) {
+	for _, raw := range []string{
+		`{"schemaVersion": 3}`,
+		`{"schemaVersion": 0}`,
+		`{"schemaVersion": 1.5}`,
+		`{"schemaVersion": "2"}`,
+		`[]`,
+	} {
+		if got, err := Upgrade([]byte(raw)); err == nil {
+			t.Errorf("Upgrade(%s) = %s, want an error", raw, got)
+		}
+	}
+}
+
+func TestUpgradeExport(t *testing.T) {
+	export := `{"schemaVersion": 1, "data": {"skills": [{"items": ["Go"]}]}, "versions": [
+		{"data": {"skills": [{"items": ["SQL"]}]}},
+		{"data": {"schemaVersion": 2, "skills": [{"items": [{"name": "Rust"}]}]}}
+	]}`
+	want := `{"schemaVersion": 2, "data": {"schemaVersion": 2, "skills": [{"items": [{"name": "Go"}]}]}, "versions": [
+		{"data": {"schemaVersion": 2, "skills": [{"items": [{"name": "SQL"}]}]}},
+		{"data": {"schemaVersion": 2, "skills": [{"items": [{"name": "Rust"}]}]}}
+	]}`
+	got, err := UpgradeExport([]byte(export))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decode(t, got), decode(t, []byte(want))) {
+		t.Errorf("UpgradeExport() = %s, want %s", got, want)
+	}
+}
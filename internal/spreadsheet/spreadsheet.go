@@ -0,0 +1,178 @@
+// Package spreadsheet reads and writes simple tables as CSV and XLSX: a
+// header row followed by rows of text cells. Only the first worksheet of
+// a workbook is read, and formatting is ignored, except that date cells
+// are read as YYYY-MM-DD.
+package spreadsheet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Limits on what is read.
+const (
+	MaxRows    = 5000
+	maxColumns = 200
+)
+
+var (
+	ErrEmpty       = errors.New("spreadsheet has no header row")
+	ErrTooManyRows = errors.New("spreadsheet has too many rows")
+	ErrInvalidXLSX = errors.New("not a valid XLSX workbook")
+)
+
+// Table is a header row and data rows. Rows may be shorter than the header.
+type Table struct {
+	Header  []string
+	Rows    [][]string
+	Numbers []int // 1-based row number of each row in the file, when read from one
+}
+
+// Cell returns the cell of row i in column j, or "" if the row is short.
+func (t *Table) Cell(i, j int) string {
+	if j < 0 || j >= len(t.Rows[i]) {
+		return ""
+	}
+	return t.Rows[i][j]
+}
+
+// newTable builds a table from raw records, trimming cells and dropping
+// blank rows. numbers gives the row number of each record; when nil,
+// records are numbered from 1.
+func newTable(records [][]string, numbers []int) (*Table, error) {
+	t := &Table{}
+	for n, rec := range records {
+		if len(rec) > maxColumns {
+			rec = rec[:maxColumns]
+		}
+		blank := true
+		for i := range rec {
+			rec[i] = strings.TrimSpace(rec[i])
+			if rec[i] != "" {
+				blank = false
+			}
+		}
+		if blank {
+			continue
+		}
+		if t.Header == nil {
+			t.Header = rec
+			continue
+		}
+		if len(t.Rows) == MaxRows {
+			return nil, ErrTooManyRows
+		}
+		t.Rows = append(t.Rows, rec)
+		if numbers != nil {
+			t.Numbers = append(t.Numbers, numbers[n])
+		} else {
+			t.Numbers = append(t.Numbers, n+1)
+		}
+	}
+	if t.Header == nil {
+		return nil, ErrEmpty
+	}
+	return t, nil
+}
+
+// ReadCSV reads a CSV file. The delimiter is detected from the header
+// line: comma, semicolon (common in European locales) or tab.
+func ReadCSV(r io.Reader) (*Table, error) {
+	br := bufio.NewReader(r)
+	if bom, _ := br.Peek(3); bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		br.Discard(3)
+	}
+	first, _ := br.Peek(4096)
+	if i := bytes.IndexByte(first, '\n'); i >= 0 {
+		first = first[:i]
+	}
+
+	cr := csv.NewReader(br)
+	cr.Comma = detectDelimiter(first)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	var records [][]string
+	var numbers []int
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(records) > MaxRows {
+			return nil, ErrTooManyRows
+		}
+		line, _ := cr.FieldPos(0)
+		for i, cell := range rec {
+			rec[i] = unescapeFormula(cell)
+		}
+		records = append(records, rec)
+		numbers = append(numbers, line)
+	}
+	return newTable(records, numbers)
+}
+
+func detectDelimiter(line []byte) rune {
+	best, count := ',', bytes.Count(line, []byte{','})
+	for _, d := range []rune{';', '\t'} {
+		if n := bytes.Count(line, []byte(string(d))); n > count {
+			best, count = d, n
+		}
+	}
+	return best
+}
+
+// WriteCSV writes t as CSV with a byte order mark, so spreadsheet
+// applications detect UTF-8. Cells that would be read as a formula are
+// escaped.
+func WriteCSV(w io.Writer, t *Table) error {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(escapeFormulas(t.Header)); err != nil {
+		return err
+	}
+	for _, row := range t.Rows {
+		if err := cw.Write(escapeFormulas(row)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// formulaStart reports whether a cell starting with c would be read as a
+// formula by spreadsheet applications.
+func formulaStart(c byte) bool {
+	return strings.IndexByte("=+-@\t\r", c) >= 0
+}
+
+// escapeFormulas prefixes cells that would be read as a formula with an
+// apostrophe, so text such as =HYPERLINK(...) in a note is shown rather
+// than run.
+func escapeFormulas(rec []string) []string {
+	out := make([]string, len(rec))
+	for i, cell := range rec {
+		if cell != "" && formulaStart(cell[0]) {
+			cell = "'" + cell
+		}
+		out[i] = cell
+	}
+	return out
+}
+
+// unescapeFormula undoes escapeFormulas, so a file written by WriteCSV
+// reads back as it was.
+func unescapeFormula(cell string) string {
+	if len(cell) > 1 && cell[0] == '\'' && formulaStart(cell[1]) {
+		return cell[1:]
+	}
+	return cell
+}
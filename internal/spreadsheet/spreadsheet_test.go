@@ -0,0 +1,64 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	table := &Table{
+		Header: []string{"Company", "Notes"},
+		Rows: [][]string{
+			{"=HYPERLINK(\"http://evil.example\",\"x\")", "+1 555 0100"},
+			{"-2+3", "@SUM(A1:A2)"},
+			{"Acme", "2025-03-04"},
+			{"", "don't"},
+		},
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, table); err != nil {
+		t.Fatal(err)
+	}
+	want := "\ufeffCompany,Notes\n" +
+		"\"'=HYPERLINK(\"\"http://evil.example\"\",\"\"x\"\")\",'+1 555 0100\n" +
+		"'-2+3,'@SUM(A1:A2)\n" +
+		"Acme,2025-03-04\n" +
+		",don't\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteCSV() =\n%s\nwant\n%s", got, want)
+	}
+
+	// The escaping is undone when the file is read back
+	got, err := ReadCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Header, table.Header) || !reflect.DeepEqual(got.Rows, table.Rows) {
+		t.Errorf("ReadCSV(WriteCSV()) = %q %q, want %q %q", got.Header, got.Rows, table.Header, table.Rows)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	in := "\ufeffCompany;Role;Applied\n" +
+		"Acme; Engineer ;2025-03-04\n" +
+		";;\n" +
+		"\"Globex; Inc\";\"Analyst\nNight shift\"\n"
+	got, err := ReadCSV(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Table{
+		Header:  []string{"Company", "Role", "Applied"},
+		Rows:    [][]string{{"Acme", "Engineer", "2025-03-04"}, {"Globex; Inc", "Analyst\nNight shift"}},
+		Numbers: []int{2, 4},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadCSV() = %+v, want %+v", got, want)
+	}
+
+	if _, err := ReadCSV(strings.NewReader("\n ,\n")); err != ErrEmpty {
+		t.Errorf("ReadCSV of a blank file error = %v, want %v", err, ErrEmpty)
+	}
+}
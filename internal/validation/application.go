@@ -0,0 +1,150 @@
+package validation
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/cv-forge/cv-forge/internal/fx"
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// Length limits for application fields.
+const (
+	maxNotesLen = 20000
+	maxURLLen   = 2048
+	maxStages   = 30
+	maxBenefits = 30
+)
+
+var hexColorRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// CreateApplication validates the payload of createApplication against the
+// user's pipeline stages.
+func CreateApplication(req models.CreateApplicationRequest, stages []models.PipelineStage) Errors {
+	var c checker
+	if c.required("/company", req.Company) {
+		c.maxLen("/company", req.Company, maxFieldLen)
+	}
+	if c.required("/role", req.Role) {
+		c.maxLen("/role", req.Role, maxFieldLen)
+	}
+	if c.required("/status", string(req.Status)) {
+		if models.FindStage(stages, req.Status) == nil {
+			names := make([]string, len(stages))
+			for i, st := range stages {
+				names[i] = st.Name
+			}
+			c.add("/status", "must be one of: %s", strings.Join(names, ", "))
+		}
+	}
+	c.maxLen("/salary", req.Salary, maxFieldLen)
+	if req.Compensation != nil {
+		c.compensation("/compensation", *req.Compensation)
+	}
+	c.maxLen("/url", req.URL, maxURLLen)
+	c.url("/url", req.URL)
+	c.maxLen("/source", req.Source, maxFieldLen)
+	c.maxLen("/location", req.Location, maxFieldLen)
+	c.maxLen("/notes", req.Notes, maxNotesLen)
+	return c.errs
+}
+
+// compensation checks a structured compensation. The currency may be left
+// out, e.g. when migrated from a salary that did not name one.
+func (c *checker) compensation(path string, comp models.Compensation) {
+	for _, f := range []struct {
+		name  string
+		value float64
+	}{{"min", comp.Min}, {"max", comp.Max}, {"bonus", comp.Bonus}, {"equity", comp.Equity}} {
+		if f.value < 0 || math.IsNaN(f.value) || math.IsInf(f.value, 0) {
+			c.add(path+"/"+f.name, "must be a non-negative number")
+		}
+	}
+	if comp.Max != 0 && comp.Max < comp.Min {
+		c.add(path+"/max", "must not be less than min")
+	}
+	if comp.Currency != "" && !fx.ValidCode(comp.Currency) {
+		c.add(path+"/currency", "must be an ISO 4217 code like EUR")
+	}
+	if !comp.Period.Valid() {
+		c.add(path+"/period", "must be one of: year, month, week, day, hour")
+	}
+	if len(comp.Benefits) > maxBenefits {
+		c.add(path+"/benefits", "must have at most %d entries", maxBenefits)
+	}
+	for i, b := range comp.Benefits {
+		bp := path + Pointer("benefits", i)
+		if c.required(bp, b) {
+			c.maxLen(bp, b, maxFieldLen)
+		}
+	}
+	c.maxLen(path+"/note", comp.Note, maxFieldLen)
+}
+
+// UpdateApplication validates the payload of updateApplication.
+func UpdateApplication(req models.UpdateApplicationRequest, stages []models.PipelineStage) Errors {
+	return CreateApplication(models.CreateApplicationRequest(req), stages)
+}
+
+// ImportURL validates the payload of importApplicationURL.
+func ImportURL(req models.ImportURLRequest, stages []models.PipelineStage) Errors {
+	var c checker
+	if c.required("/url", req.URL) {
+		c.maxLen("/url", req.URL, maxURLLen)
+		c.url("/url", req.URL)
+	}
+	if req.Status != "" && models.FindStage(stages, req.Status) == nil {
+		c.add("/status", "must be a stage of your pipeline")
+	}
+	return c.errs
+}
+
+// Event validates the payload of createEvent.
+func Event(req models.CreateEventRequest) Errors {
+	var c checker
+	if !req.Type.Manual() {
+		c.add("/type", "must be one of: call, email, interview, note")
+	}
+	c.maxLen("/note", req.Note, maxNotesLen)
+	return c.errs
+}
+
+// Pipeline validates the payload of updatePipeline.
+func Pipeline(req models.UpdatePipelineRequest) Errors {
+	var c checker
+	if len(req.Stages) == 0 {
+		c.add("/stages", "must have at least one stage")
+		return c.errs
+	}
+	if len(req.Stages) > maxStages {
+		c.add("/stages", "must have at most %d stages", maxStages)
+	}
+
+	seen := map[string]bool{}
+	open := false
+	for i, s := range req.Stages {
+		path := Pointer("stages", i)
+		if c.required(path+"/name", s.Name) {
+			c.maxLen(path+"/name", s.Name, maxNameLen)
+			key := strings.ToLower(strings.TrimSpace(s.Name))
+			if seen[key] {
+				c.add(path+"/name", "duplicate stage name")
+			}
+			seen[key] = true
+		}
+		if s.Color != "" && !hexColorRe.MatchString(s.Color) {
+			c.add(path+"/color", "must be a hex color like #3b82f6")
+		}
+		if !s.Category.Valid() {
+			c.add(path+"/category", "must be one of: applied, interview, offer, rejected, closed")
+		}
+		if !s.Terminal {
+			open = true
+		}
+	}
+	if !open {
+		c.add("/stages", "must have at least one non-terminal stage")
+	}
+	return c.errs
+}
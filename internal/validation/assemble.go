@@ -0,0 +1,44 @@
+package validation
+
+import (
+	"strings"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// Assemble validates the payload of an assembly request.
+func Assemble(req models.AssembleRequest) Errors {
+	var c checker
+	if len(req.Tags) == 0 {
+		c.add("/tags", "is required")
+	}
+	c.tags("/tags", req.Tags)
+	if req.MaxPages < 0 || req.MaxPages > 20 {
+		c.add("/maxPages", "must be between 0 and 20")
+	}
+	if req.MaxEntries < 0 {
+		c.add("/maxEntries", "must not be negative")
+	}
+	if req.MaxBulletsPerEntry < 0 {
+		c.add("/maxBulletsPerEntry", "must not be negative")
+	}
+	switch req.Target {
+	case "", "cv", "version":
+	default:
+		c.add("/target", "must be cv or version")
+	}
+	c.maxLen("/title", req.Title, maxTitleLen)
+	c.maxLen("/message", req.Message, maxFieldLen)
+	return c.errs
+}
+
+// KeywordMatch validates a keyword match request. The job description must
+// be pasted or come from an application.
+func KeywordMatch(req models.KeywordMatchRequest) Errors {
+	var c checker
+	if strings.TrimSpace(req.JobDescription) == "" && (req.ApplicationID == nil || *req.ApplicationID == "") {
+		c.add("/jobDescription", "is required unless applicationId is given")
+	}
+	c.maxLen("/jobDescription", req.JobDescription, maxNotesLen)
+	return c.errs
+}
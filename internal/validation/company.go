@@ -0,0 +1,41 @@
+package validation
+
+import "github.com/cv-forge/cv-forge/internal/models"
+
+const maxAliases = 20
+
+// Company validates the payload of createCompany and updateCompany.
+func Company(req models.CompanyRequest) Errors {
+	var c checker
+	if c.required("/name", req.Name) {
+		c.maxLen("/name", req.Name, maxFieldLen)
+	}
+	if len(req.Aliases) > maxAliases {
+		c.add("/aliases", "must have at most %d entries", maxAliases)
+	}
+	for i, a := range req.Aliases {
+		if c.required(Pointer("aliases", i), a) {
+			c.maxLen(Pointer("aliases", i), a, maxFieldLen)
+		}
+	}
+	c.maxLen("/website", req.Website, maxURLLen)
+	c.url("/website", req.Website)
+	c.maxLen("/industry", req.Industry, maxFieldLen)
+	c.maxLen("/size", req.Size, maxNameLen)
+	c.maxLen("/location", req.Location, maxFieldLen)
+	c.maxLen("/notes", req.Notes, maxNotesLen)
+	return c.errs
+}
+
+// MergeCompanies validates the payload of mergeCompanies.
+func MergeCompanies(req models.MergeCompaniesRequest) Errors {
+	var c checker
+	c.required("/targetId", req.TargetID)
+	if len(req.SourceIDs) == 0 {
+		c.add("/sourceIds", "must have at least one company")
+	}
+	for i, id := range req.SourceIDs {
+		c.required(Pointer("sourceIds", i), id)
+	}
+	return c.errs
+}
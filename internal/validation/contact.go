@@ -0,0 +1,39 @@
+package validation
+
+import "github.com/cv-forge/cv-forge/internal/models"
+
+// Contact validates the payload of createContact and updateContact.
+func Contact(req models.ContactRequest) Errors {
+	var c checker
+	if c.required("/name", req.Name) {
+		c.maxLen("/name", req.Name, maxNameLen)
+	}
+	c.maxLen("/company", req.Company, maxFieldLen)
+	c.maxLen("/role", req.Role, maxFieldLen)
+	c.maxLen("/email", req.Email, maxFieldLen)
+	c.email("/email", req.Email)
+	c.phone("/phone", req.Phone)
+	c.maxLen("/linkedIn", req.LinkedIn, maxURLLen)
+	c.url("/linkedIn", req.LinkedIn)
+	c.maxLen("/notes", req.Notes, maxNotesLen)
+	return c.errs
+}
+
+// LinkContact validates the payload of linkContact.
+func LinkContact(req models.LinkContactRequest) Errors {
+	var c checker
+	if !req.Relationship.Valid() {
+		c.add("/relationship", "must be one of: recruiter, hiring_manager, interviewer, referral, other")
+	}
+	return c.errs
+}
+
+// Interaction validates the payload of createInteraction.
+func Interaction(req models.InteractionRequest) Errors {
+	var c checker
+	if !req.Type.Valid() {
+		c.add("/type", "must be one of: call, email, meeting, message, other")
+	}
+	c.maxLen("/summary", req.Summary, maxNotesLen)
+	return c.errs
+}
@@ -0,0 +1,50 @@
+package validation
+
+import (
+	"strings"
+
+	"github.com/cv-forge/cv-forge/internal/coverletter"
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// maxLetterLen bounds cover letter and template bodies.
+const maxLetterLen = 20000
+
+// CoverLetterTemplate validates the payload of a cover letter template,
+// including that its merge fields exist.
+func CoverLetterTemplate(req models.CoverLetterTemplateRequest) Errors {
+	var c checker
+	if c.required("/name", req.Name) {
+		c.maxLen("/name", req.Name, maxFieldLen)
+	}
+	if c.required("/body", req.Body) {
+		c.maxLen("/body", req.Body, maxLetterLen)
+		if err := coverletter.Validate(req.Body); err != nil {
+			c.add("/body", "%s", err.Error())
+		}
+	}
+	return c.errs
+}
+
+// CreateCoverLetter validates the payload of a new cover letter.
+func CreateCoverLetter(req models.CreateCoverLetterRequest) Errors {
+	var c checker
+	if strings.TrimSpace(req.Body) == "" && (req.TemplateID == nil || *req.TemplateID == "") {
+		c.add("/body", "is required unless templateId is given")
+	}
+	c.maxLen("/title", req.Title, maxTitleLen)
+	c.maxLen("/body", req.Body, maxLetterLen)
+	return c.errs
+}
+
+// UpdateCoverLetter validates the payload of a cover letter update.
+func UpdateCoverLetter(req models.UpdateCoverLetterRequest) Errors {
+	var c checker
+	if c.required("/title", req.Title) {
+		c.maxLen("/title", req.Title, maxTitleLen)
+	}
+	if c.required("/body", req.Body) {
+		c.maxLen("/body", req.Body, maxLetterLen)
+	}
+	return c.errs
+}
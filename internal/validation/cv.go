@@ -0,0 +1,223 @@
+package validation
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// Length limits for CV content.
+const (
+	maxTitleLen       = 200
+	maxNameLen        = 100
+	maxFieldLen       = 200
+	maxSummaryLen     = 5000
+	maxDescriptionLen = 10000
+	maxBulletLen      = 1000
+	maxLabelLen       = 100
+)
+
+// CV validates a CV title and its content.
+func CV(title string, data models.CVData) Errors {
+	var c checker
+	c.maxLen("/title", title, maxTitleLen)
+	c.cvData("/data", data)
+	return c.errs
+}
+
+// CVData validates CV content. Paths are relative to the CVData document.
+func CVData(data models.CVData) Errors {
+	var c checker
+	c.cvData("", data)
+	return c.errs
+}
+
+// cvData validates d, prefixing every path with base.
+func (c *checker) cvData(base string, d models.CVData) {
+	p := func(segments ...any) string {
+		return base + Pointer(segments...)
+	}
+
+	pi := d.Personal
+	c.maxLen(p("personal", "firstName"), pi.FirstName, maxNameLen)
+	c.maxLen(p("personal", "lastName"), pi.LastName, maxNameLen)
+	c.maxLen(p("personal", "title"), pi.Title, maxFieldLen)
+	c.maxLen(p("personal", "location"), pi.Location, maxFieldLen)
+	c.email(p("personal", "email"), pi.Email)
+	c.phone(p("personal", "phone"), pi.Phone)
+	c.url(p("personal", "linkedin"), pi.LinkedIn)
+	c.url(p("personal", "website"), pi.Website)
+	c.maxLen(p("personal", "photo"), pi.Photo, 64)
+
+	c.maxLen(p("summary"), d.Summary, maxSummaryLen)
+
+	for i, e := range d.Experience {
+		c.experience(p("experience", i), e)
+	}
+
+	for i, e := range d.Education {
+		c.maxLen(p("education", i, "institution"), e.Institution, maxFieldLen)
+		c.maxLen(p("education", i, "degree"), e.Degree, maxFieldLen)
+		c.maxLen(p("education", i, "field"), e.Field, maxFieldLen)
+		c.maxLen(p("education", i, "description"), e.Description, maxDescriptionLen)
+		c.dateRange(p("education", i), e.StartDate, e.EndDate)
+	}
+
+	for i, g := range d.Skills {
+		c.skillGroup(p("skills", i), g)
+	}
+
+	for i, l := range d.Languages {
+		c.maxLen(p("languages", i, "language"), l.Language, maxNameLen)
+		c.maxLen(p("languages", i, "proficiency"), l.Proficiency, maxNameLen)
+	}
+
+	for i, cert := range d.Certifications {
+		c.maxLen(p("certifications", i, "name"), cert.Name, maxFieldLen)
+		c.maxLen(p("certifications", i, "issuer"), cert.Issuer, maxFieldLen)
+		c.date(p("certifications", i, "date"), cert.Date)
+		c.url(p("certifications", i, "url"), cert.URL)
+	}
+
+	if d.Style != nil {
+		styles := []struct {
+			name  string
+			style models.FontStyle
+		}{
+			{"title1", d.Style.Title1},
+			{"title2", d.Style.Title2},
+			{"title3", d.Style.Title3},
+			{"text1", d.Style.Text1},
+			{"text2", d.Style.Text2},
+			{"sub", d.Style.Sub},
+		}
+		for _, s := range styles {
+			c.fontStyle(p("style", s.name), s.style)
+		}
+	}
+
+	if d.Labels != nil {
+		c.labels(p("labels"), *d.Labels)
+	}
+
+	c.locale(p("defaultLocale"), d.DefaultLocale)
+	keys := make([]string, 0, len(d.Locales))
+	for key := range d.Locales {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		loc := d.Locales[key]
+		c.locale(p("locales", key), key)
+		c.maxLen(p("locales", key, "title"), loc.Title, maxFieldLen)
+		c.maxLen(p("locales", key, "summary"), loc.Summary, maxSummaryLen)
+		if len(loc.Experience) > len(d.Experience) {
+			c.add(p("locales", key, "experience"), "has more entries than experience")
+		}
+		for i, e := range loc.Experience {
+			c.maxLen(p("locales", key, "experience", i, "title"), e.Title, maxFieldLen)
+			c.maxLen(p("locales", key, "experience", i, "description"), e.Description, maxDescriptionLen)
+		}
+		if len(loc.Education) > len(d.Education) {
+			c.add(p("locales", key, "education"), "has more entries than education")
+		}
+		for i, e := range loc.Education {
+			c.maxLen(p("locales", key, "education", i, "degree"), e.Degree, maxFieldLen)
+			c.maxLen(p("locales", key, "education", i, "field"), e.Field, maxFieldLen)
+			c.maxLen(p("locales", key, "education", i, "description"), e.Description, maxDescriptionLen)
+		}
+		if len(loc.SkillCategories) > len(d.Skills) {
+			c.add(p("locales", key, "skillCategories"), "has more entries than skills")
+		}
+		if loc.Labels != nil {
+			c.labels(p("locales", key, "labels"), *loc.Labels)
+		}
+	}
+}
+
+func (c *checker) experience(path string, e models.Experience) {
+	c.maxLen(path+"/company", e.Company, maxFieldLen)
+	c.maxLen(path+"/title", e.Title, maxFieldLen)
+	c.maxLen(path+"/location", e.Location, maxFieldLen)
+	c.maxLen(path+"/description", e.Description, maxDescriptionLen)
+	end := e.EndDate
+	if e.Current {
+		end = ""
+	}
+	c.dateRange(path, e.StartDate, end)
+	c.tags(path+"/tags", e.Tags)
+	for i, b := range e.Bullets {
+		bp := path + Pointer("bullets", i)
+		if c.required(bp+"/text", b.Text) {
+			c.maxLen(bp+"/text", b.Text, maxBulletLen)
+		}
+		c.tags(bp+"/tags", b.Tags)
+	}
+}
+
+func (c *checker) skillGroup(path string, g models.SkillGroup) {
+	c.maxLen(path+"/category", g.Category, maxNameLen)
+	thisYear := time.Now().Year()
+	for j, s := range g.Items {
+		sp := path + Pointer("items", j)
+		if c.required(sp+"/name", s.Name) {
+			c.maxLen(sp+"/name", s.Name, maxNameLen)
+		}
+		switch s.Level {
+		case "", models.SkillBeginner, models.SkillIntermediate, models.SkillAdvanced, models.SkillExpert:
+		default:
+			c.add(sp+"/level", "must be one of beginner, intermediate, advanced, expert")
+		}
+		if s.Years < 0 || s.Years > 80 {
+			c.add(sp+"/years", "must be between 0 and 80")
+		}
+		if s.LastUsed != 0 && (s.LastUsed < 1950 || s.LastUsed > thisYear+1) {
+			c.add(sp+"/lastUsed", "must be a year between 1950 and %d", thisYear+1)
+		}
+		c.tags(sp+"/tags", s.Tags)
+	}
+}
+
+// fontStyle checks the fields a style sets. A missing style, size or color
+// is left out and takes the default when the CV is shown, as older exports
+// and CVs saved before some styles existed rely on.
+func (c *checker) fontStyle(path string, s models.FontStyle) {
+	if s.Size < 0 || s.Size > 96 {
+		c.add(path+"/size", "must be between 1 and 96 when set")
+	}
+	if len(s.Color) == 0 {
+		return
+	}
+	if len(s.Color) != 3 {
+		c.add(path+"/color", "must have exactly 3 values")
+		return
+	}
+	for i, v := range s.Color {
+		if v < 0 || v > 255 {
+			c.add(path+Pointer("color", i), "must be between 0 and 255")
+		}
+	}
+}
+
+func (c *checker) labels(path string, l models.SectionLabels) {
+	c.maxLen(path+"/summary", l.Summary, maxLabelLen)
+	c.maxLen(path+"/experience", l.Experience, maxLabelLen)
+	c.maxLen(path+"/education", l.Education, maxLabelLen)
+	c.maxLen(path+"/skills", l.Skills, maxLabelLen)
+	c.maxLen(path+"/languages", l.Languages, maxLabelLen)
+	c.maxLen(path+"/certifications", l.Certifications, maxLabelLen)
+	c.maxLen(path+"/present", l.Present, maxLabelLen)
+}
+
+// CVExport validates an exported CV before it is imported, including the
+// data of every version it carries.
+func CVExport(e models.CVExport) Errors {
+	var c checker
+	c.maxLen("/title", e.Title, maxTitleLen)
+	c.cvData("/data", e.Data)
+	for i, v := range e.Versions {
+		c.cvData(Pointer("versions", i, "data"), v.Data)
+	}
+	return c.errs
+}
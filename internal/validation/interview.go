@@ -0,0 +1,55 @@
+package validation
+
+import (
+	"time"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// Limits for interview fields.
+const (
+	maxInterviewers     = 20
+	maxInterviewLength  = 24 * time.Hour
+	maxInterviewNoteLen = 10000
+)
+
+// Interview validates the payload of createInterview and updateInterview.
+func Interview(req models.InterviewRequest) Errors {
+	var c checker
+	if !req.Type.Valid() {
+		c.add("/type", "must be one of: phone_screen, technical, behavioral, onsite, panel, final, other")
+	}
+	if req.StartsAt.IsZero() {
+		c.add("/startsAt", "is required")
+	}
+	if req.EndsAt.IsZero() {
+		c.add("/endsAt", "is required")
+	} else if !req.StartsAt.IsZero() {
+		if !req.EndsAt.After(req.StartsAt) {
+			c.add("/endsAt", "must be after startsAt")
+		} else if req.EndsAt.Sub(req.StartsAt) > maxInterviewLength {
+			c.add("/endsAt", "must be within 24 hours of startsAt")
+		}
+	}
+	if req.Timezone != "" {
+		if _, err := time.LoadLocation(req.Timezone); err != nil {
+			c.add("/timezone", "must be an IANA time zone like Europe/Madrid")
+		}
+	}
+	c.maxLen("/location", req.Location, maxFieldLen)
+	c.maxLen("/videoLink", req.VideoLink, maxURLLen)
+	c.url("/videoLink", req.VideoLink)
+	if len(req.Interviewers) > maxInterviewers {
+		c.add("/interviewers", "must have at most %d entries", maxInterviewers)
+	}
+	for i, name := range req.Interviewers {
+		if c.required(Pointer("interviewers", i), name) {
+			c.maxLen(Pointer("interviewers", i), name, maxNameLen)
+		}
+	}
+	if !req.Outcome.Valid() {
+		c.add("/outcome", "must be one of: pending, passed, failed, cancelled")
+	}
+	c.maxLen("/notes", req.Notes, maxInterviewNoteLen)
+	return c.errs
+}
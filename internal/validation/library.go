@@ -0,0 +1,45 @@
+package validation
+
+import "github.com/cv-forge/cv-forge/internal/models"
+
+// LibraryItem validates the payload of a library item.
+func LibraryItem(req models.LibraryItemRequest) Errors {
+	var c checker
+	if !req.Kind.Valid() {
+		c.add("/kind", "must be one of experience, bullet, summary, skills")
+	}
+	c.maxLen("/name", req.Name, maxFieldLen)
+
+	switch req.Kind {
+	case models.LibraryExperience:
+		if req.Content.Experience == nil {
+			c.add("/content/experience", "is required")
+		} else {
+			c.experience("/content/experience", *req.Content.Experience)
+		}
+	case models.LibraryBullet:
+		if c.required("/content/text", req.Content.Text) {
+			c.maxLen("/content/text", req.Content.Text, maxBulletLen)
+		}
+	case models.LibrarySummary:
+		if c.required("/content/text", req.Content.Text) {
+			c.maxLen("/content/text", req.Content.Text, maxSummaryLen)
+		}
+	case models.LibrarySkills:
+		if req.Content.Skills == nil {
+			c.add("/content/skills", "is required")
+		} else {
+			c.skillGroup("/content/skills", *req.Content.Skills)
+		}
+	}
+	return c.errs
+}
+
+// LibraryRefs turns the paths of unresolved library references into errors.
+func LibraryRefs(base string, missing []string) Errors {
+	var errs Errors
+	for _, path := range missing {
+		errs = append(errs, FieldError{Path: base + path, Message: "references an unknown library item"})
+	}
+	return errs
+}
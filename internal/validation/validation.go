@@ -0,0 +1,161 @@
+// Package validation checks request payloads and reports field-level errors
+// addressed by JSON pointer (RFC 6901), e.g. "/experience/0/startDate".
+package validation
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cv-forge/cv-forge/internal/models"
+)
+
+// FieldError describes a problem with a single field.
+type FieldError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Errors is a list of field errors. An empty list means the value is valid.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Path + ": " + fe.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Pointer builds a JSON pointer from path segments, escaping as needed.
+func Pointer(segments ...any) string {
+	var b strings.Builder
+	for _, s := range segments {
+		b.WriteByte('/')
+		seg := fmt.Sprint(s)
+		seg = strings.ReplaceAll(seg, "~", "~0")
+		seg = strings.ReplaceAll(seg, "/", "~1")
+		b.WriteString(seg)
+	}
+	return b.String()
+}
+
+// checker accumulates errors for a payload.
+type checker struct {
+	errs Errors
+}
+
+func (c *checker) add(path, format string, args ...any) {
+	c.errs = append(c.errs, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *checker) required(path, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		c.add(path, "is required")
+		return false
+	}
+	return true
+}
+
+func (c *checker) maxLen(path, value string, n int) {
+	if utf8.RuneCountInString(value) > n {
+		c.add(path, "must be at most %d characters", n)
+	}
+}
+
+func (c *checker) email(path, value string) {
+	if value == "" {
+		return
+	}
+	addr, err := mail.ParseAddress(value)
+	if err != nil || addr.Address != value {
+		c.add(path, "must be a valid email address")
+	}
+}
+
+// url accepts absolute http(s) URLs. A missing scheme is tolerated since
+// people commonly write "linkedin.com/in/someone".
+func (c *checker) url(path, value string) {
+	if value == "" {
+		return
+	}
+	raw := value
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || (!strings.Contains(u.Host, ".") && u.Hostname() != "localhost") {
+		c.add(path, "must be a valid http or https URL")
+	}
+}
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9 ().\-/]+$`)
+
+func (c *checker) phone(path, value string) {
+	if value == "" {
+		return
+	}
+	digits := 0
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	if !phonePattern.MatchString(value) || digits < 6 || digits > 20 {
+		c.add(path, "must be a valid phone number")
+	}
+}
+
+// date checks a CV entry date and returns whether it parsed.
+func (c *checker) date(path, value string) bool {
+	if value == "" {
+		return false
+	}
+	if _, ok := models.ParseCVDate(value); !ok {
+		c.add(path, "must be a date such as 2024-05")
+		return false
+	}
+	return true
+}
+
+// dateRange checks the startDate and endDate fields of the entry at base.
+func (c *checker) dateRange(base, start, end string) {
+	okStart := c.date(base+"/startDate", start)
+	okEnd := c.date(base+"/endDate", end)
+	if okStart && okEnd {
+		s, _ := models.ParseCVDate(start)
+		e, _ := models.ParseCVDate(end)
+		if e.Before(s) {
+			c.add(base+"/endDate", "must not be before startDate")
+		}
+	}
+}
+
+// Limits for tags on CV content.
+const (
+	maxTags   = 30
+	maxTagLen = 50
+)
+
+func (c *checker) tags(path string, tags []string) {
+	if len(tags) > maxTags {
+		c.add(path, "must have at most %d tags", maxTags)
+	}
+	for i, t := range tags {
+		tp := path + Pointer(i)
+		if c.required(tp, t) {
+			c.maxLen(tp, t, maxTagLen)
+		}
+	}
+}
+
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z0-9]{2,8})*$`)
+
+func (c *checker) locale(path, value string) {
+	if value != "" && !localePattern.MatchString(value) {
+		c.add(path, "must be a language tag such as en or de-AT")
+	}
+}
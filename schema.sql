@@ -20,15 +20,187 @@ CREATE TABLE IF NOT EXISTS cv_versions (
 
 CREATE TABLE IF NOT EXISTS applications (
     id TEXT PRIMARY KEY,
-    company TEXT NOT NULL,
+    company_id TEXT REFERENCES companies(id) ON DELETE SET NULL,
+    company TEXT NOT NULL, -- name of the company, kept in sync with companies.name
     role TEXT NOT NULL,
-    status TEXT NOT NULL, -- 'Applied', 'Interviewing', 'Rejected', 'Offer'
-    salary TEXT,
+    status TEXT NOT NULL, -- name of one of the user's pipeline_stages
+    salary TEXT, -- legacy free text, migrated into compensation
+    compensation TEXT, -- JSON-encoded Compensation
     url TEXT,
+    source TEXT NOT NULL DEFAULT '', -- where the posting was found
+    location TEXT NOT NULL DEFAULT '',
     date DATETIME DEFAULT CURRENT_TIMESTAMP,
     notes TEXT,
+    deadline DATETIME,
+    follow_up_at DATETIME,
     cv_id TEXT REFERENCES cvs(id) ON DELETE SET NULL,
     cv_version_id TEXT REFERENCES cv_versions(id) ON DELETE SET NULL,
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 );
+
+CREATE TABLE IF NOT EXISTS label_packs (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    locale TEXT NOT NULL DEFAULT '',
+    labels TEXT NOT NULL, -- JSON-encoded SectionLabels
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS blobs (
+    id TEXT PRIMARY KEY, -- also the key in the blob store
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    filename TEXT NOT NULL DEFAULT '',
+    content_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS application_attachments (
+    id TEXT PRIMARY KEY,
+    application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    blob_id TEXT NOT NULL REFERENCES blobs(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL, -- 'cover_letter', 'job_description', 'offer_letter', 'assignment', 'other'
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS library_items (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL, -- 'experience', 'bullet', 'summary', 'skills'
+    name TEXT NOT NULL DEFAULT '',
+    content TEXT NOT NULL, -- JSON-encoded LibraryContent
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS pipeline_stages (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL, -- matched by applications.status
+    position INTEGER NOT NULL,
+    terminal BOOLEAN NOT NULL DEFAULT 0,
+    color TEXT NOT NULL DEFAULT '',
+    category TEXT NOT NULL, -- 'applied', 'interview', 'offer', 'rejected', 'closed'
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS application_events (
+    id TEXT PRIMARY KEY,
+    application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    type TEXT NOT NULL, -- 'created', 'status_change', 'field_change', 'call', 'email', 'interview', 'note'
+    field TEXT NOT NULL DEFAULT '',
+    from_value TEXT NOT NULL DEFAULT '',
+    to_value TEXT NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    occurred_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_application_events_application ON application_events(application_id);
+
+CREATE TABLE IF NOT EXISTS interviews (
+    id TEXT PRIMARY KEY,
+    application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    type TEXT NOT NULL, -- 'phone_screen', 'technical', 'behavioral', 'onsite', 'panel', 'final', 'other'
+    starts_at DATETIME NOT NULL, -- UTC
+    ends_at DATETIME NOT NULL, -- UTC
+    timezone TEXT NOT NULL DEFAULT '', -- IANA zone the interview is held in
+    location TEXT NOT NULL DEFAULT '',
+    video_link TEXT NOT NULL DEFAULT '',
+    interviewers TEXT NOT NULL DEFAULT '[]', -- JSON array of names
+    outcome TEXT NOT NULL, -- 'pending', 'passed', 'failed', 'cancelled'
+    notes TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS calendar_tokens (
+    user_id TEXT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE, -- hex SHA-256 of the feed token
+    created_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS notifications_sent (
+    key TEXT PRIMARY KEY, -- e.g. 'followup:<application id>:<unix time>', 'digest:<user id>:<ISO week>'
+    sent_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS contacts (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    company TEXT NOT NULL DEFAULT '',
+    role TEXT NOT NULL DEFAULT '',
+    email TEXT NOT NULL DEFAULT '',
+    phone TEXT NOT NULL DEFAULT '',
+    linkedin TEXT NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS application_contacts (
+    application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    contact_id TEXT NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+    relationship TEXT NOT NULL, -- 'recruiter', 'hiring_manager', 'interviewer', 'referral', 'other'
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (application_id, contact_id)
+);
+
+CREATE TABLE IF NOT EXISTS contact_interactions (
+    id TEXT PRIMARY KEY,
+    contact_id TEXT NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+    application_id TEXT REFERENCES applications(id) ON DELETE SET NULL,
+    type TEXT NOT NULL, -- 'call', 'email', 'meeting', 'message', 'other'
+    summary TEXT NOT NULL DEFAULT '',
+    occurred_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS companies (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    aliases TEXT NOT NULL DEFAULT '[]', -- JSON array of other spellings
+    website TEXT NOT NULL DEFAULT '',
+    industry TEXT NOT NULL DEFAULT '',
+    size TEXT NOT NULL DEFAULT '',
+    location TEXT NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS cover_letter_templates (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    body TEXT NOT NULL, -- text/template with merge fields such as {{.Company}}
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS cover_letters (
+    id TEXT PRIMARY KEY,
+    application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    template_id TEXT REFERENCES cover_letter_templates(id) ON DELETE SET NULL,
+    cv_id TEXT REFERENCES cvs(id) ON DELETE SET NULL,
+    cv_version_id TEXT REFERENCES cv_versions(id) ON DELETE SET NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL, -- Plain text, filled in from the template
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_cover_letters_application ON cover_letters(application_id);
+
+CREATE TABLE IF NOT EXISTS cover_letter_versions (
+    id TEXT PRIMARY KEY,
+    cover_letter_id TEXT NOT NULL REFERENCES cover_letters(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL
+);
@@ -2,6 +2,7 @@ import { useRef, useLayoutEffect } from 'react';
 import type { CVData, FontStyle } from '../../types';
 import { validateAndMergeStyle } from '../../validation';
 import { defaultLabels } from '../../types';
+import { skillName } from '../../utils/skills';
 import './CVPreview.scss';
 
 interface CVPreviewProps {
@@ -110,7 +111,7 @@ export function CVPreview({ data }: CVPreviewProps) {
                             <div key={i} className="cv-preview__entry">
                                 <ul className="cv-preview__bullets">
                                     <li>
-                                        {sg.category}: {sg.items.join(', ')}
+                                        {sg.category}: {sg.items.map(skillName).join(', ')}
                                     </li>
                                 </ul>
                             </div>
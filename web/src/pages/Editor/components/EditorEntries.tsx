@@ -1,6 +1,7 @@
 import { useState } from 'react';
 import { FormInput } from '../../../components/FormInput';
 import type { Experience, Education, SkillGroup, Language, Certification } from '../../../types';
+import { skillName } from '../../../utils/skills';
 
 export function ExperienceEntry({ index, entry, onChange, onRemove }: {
     index: number; entry: Experience; onChange: (e: Experience) => void; onRemove: () => void;
@@ -84,7 +85,7 @@ export function SkillGroupEntry({ index, entry, onChange, onRemove }: {
             <div className="skill-tags">
                 {entry.items.map((skill, i) => (
                     <span key={i} className="skill-tag">
-                        {skill}
+                        {skillName(skill)}
                         <button className="skill-tag__remove" onClick={() => onChange({ ...entry, items: entry.items.filter((_, j) => j !== i) })}>✕</button>
                     </span>
                 ))}
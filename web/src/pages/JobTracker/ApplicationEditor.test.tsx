@@ -56,8 +56,10 @@ describe('Feature: Application Editor', () => {
             role: 'Engineer',
             status: ApplicationStatus.Applied,
             date: new Date().toISOString(),
-            salary: '',
+            compensation: null,
             url: '',
+            source: '',
+            location: '',
             notes: ''
         };
         (api.listCVs as any).mockResolvedValue([]);
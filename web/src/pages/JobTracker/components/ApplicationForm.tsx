@@ -2,6 +2,7 @@ import { useState, useEffect } from 'react';
 import { Application, ApplicationStatus, CV, CVVersion } from '../../../types';
 import { FormInput } from '../../../components/FormInput';
 import { api } from '../../../api';
+import { formatCompensation } from '../../../utils/compensation';
 
 interface ApplicationFormProps {
     initialData?: Application;
@@ -17,8 +18,12 @@ export function ApplicationForm({ initialData, onSubmit, onCancel, cvs }: Applic
         status: ApplicationStatus.Applied,
         salary: '',
         url: '',
+        source: '',
+        location: '',
         date: new Date().toISOString().split('T')[0],
         notes: '',
+        deadline: '',
+        followUpAt: '',
         cvId: '',
         cvVersionId: '',
     });
@@ -32,10 +37,14 @@ export function ApplicationForm({ initialData, onSubmit, onCancel, cvs }: Applic
                 company: initialData.company,
                 role: initialData.role,
                 status: initialData.status,
-                salary: initialData.salary,
+                salary: formatCompensation(initialData.compensation),
                 url: initialData.url,
+                source: initialData.source || '',
+                location: initialData.location || '',
                 date: initialData.date ? new Date(initialData.date).toISOString().split('T')[0] : '',
                 notes: initialData.notes,
+                deadline: initialData.deadline ? new Date(initialData.deadline).toISOString().split('T')[0] : '',
+                followUpAt: initialData.followUpAt ? new Date(initialData.followUpAt).toISOString().split('T')[0] : '',
                 cvId: initialData.cvId || '',
                 cvVersionId: initialData.cvVersionId || '',
             });
@@ -55,9 +64,17 @@ export function ApplicationForm({ initialData, onSubmit, onCancel, cvs }: Applic
         e.preventDefault();
         setLoading(true);
         try {
+            // Structured compensation is kept unless the salary text was edited
+            const initialSalary = formatCompensation(initialData?.compensation);
+            const salary = formData.salary === initialSalary
+                ? { compensation: initialData?.compensation ?? null }
+                : { compensation: null, salary: formData.salary };
             await onSubmit({
                 ...formData,
+                ...salary,
                 date: new Date(formData.date).toISOString(),
+                deadline: formData.deadline ? new Date(formData.deadline).toISOString() : null,
+                followUpAt: formData.followUpAt ? new Date(formData.followUpAt).toISOString() : null,
             });
         } finally {
             setLoading(false);
@@ -117,6 +134,36 @@ export function ApplicationForm({ initialData, onSubmit, onCancel, cvs }: Applic
                 />
             </div>
 
+            <div className="form-grid" data-cols="2">
+                <FormInput
+                    label="Source"
+                    value={formData.source}
+                    onChange={v => setFormData({ ...formData, source: v })}
+                    placeholder="e.g. LinkedIn, Referral"
+                />
+                <FormInput
+                    label="Location"
+                    value={formData.location}
+                    onChange={v => setFormData({ ...formData, location: v })}
+                    placeholder="e.g. Berlin, Remote"
+                />
+            </div>
+
+            <div className="form-grid" data-cols="2">
+                <FormInput
+                    label="Deadline"
+                    type="date"
+                    value={formData.deadline}
+                    onChange={v => setFormData({ ...formData, deadline: v })}
+                />
+                <FormInput
+                    label="Follow Up On"
+                    type="date"
+                    value={formData.followUpAt}
+                    onChange={v => setFormData({ ...formData, followUpAt: v })}
+                />
+            </div>
+
             <div className="form-group">
                 <label>Linked CV (Optional)</label>
                 <div className="form-grid" data-cols="2">
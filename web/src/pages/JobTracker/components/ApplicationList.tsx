@@ -1,4 +1,5 @@
 import { Application, ApplicationStatus } from '../../../types';
+import { formatCompensation } from '../../../utils/compensation';
 
 interface ApplicationListProps {
     applications: Application[];
@@ -44,7 +45,7 @@ export function ApplicationList({ applications, onEdit, onDelete }: ApplicationL
                         <tr key={app.id}>
                             <td>
                                 <div className="font-medium">{app.company}</div>
-                                {app.salary && <div className="text-sm text-gray-500">{app.salary}</div>}
+                                {app.compensation && <div className="text-sm text-gray-500">{formatCompensation(app.compensation)}</div>}
                             </td>
                             <td>
                                 <div>{app.role}</div>
@@ -28,9 +28,20 @@ export interface Education {
     description: string;
 }
 
+export type SkillLevel = 'beginner' | 'intermediate' | 'advanced' | 'expert';
+
+// A skill is sent as a plain name unless it carries metadata.
+export type Skill = string | {
+    name: string;
+    level?: SkillLevel;
+    years?: number;
+    lastUsed?: number;
+    tags?: string[];
+};
+
 export interface SkillGroup {
     category: string;
-    items: string[];
+    items: Skill[];
 }
 
 export interface Language {
@@ -113,15 +124,32 @@ export enum ApplicationStatus {
     Offer = "Offer",
 }
 
+export type PayPeriod = 'year' | 'month' | 'week' | 'day' | 'hour';
+
+export interface Compensation {
+    min: number;
+    max: number;
+    currency: string;
+    period: PayPeriod;
+    bonus: number;
+    equity: number;
+    benefits: string[];
+    note: string;
+}
+
 export interface Application {
     id: string;
     company: string;
     role: string;
     status: ApplicationStatus;
-    salary: string;
+    compensation: Compensation | null;
     url: string;
+    source: string;
+    location: string;
     date: string;
     notes: string;
+    deadline?: string | null;
+    followUpAt?: string | null;
     cvId?: string;
     cvVersionId?: string;
     createdAt: string;
@@ -132,10 +160,15 @@ export interface CreateApplicationRequest {
     company: string;
     role: string;
     status: ApplicationStatus;
-    salary: string;
+    compensation?: Compensation | null;
+    salary?: string; // Free text, parsed by the server when compensation is not sent
     url: string;
+    source: string;
+    location: string;
     date: string;
     notes: string;
+    deadline?: string | null;
+    followUpAt?: string | null;
     cvId?: string;
     cvVersionId?: string;
 }
@@ -0,0 +1,14 @@
+import { Compensation } from '../types';
+
+// One-line summary of a compensation, e.g. "EUR 50000-60000/year + 5000 bonus".
+export function formatCompensation(c?: Compensation | null): string {
+    if (!c) return '';
+    if (!c.min && !c.max) return c.note;
+    let s = c.currency ? `${c.currency} ` : '';
+    s += String(c.min);
+    if (c.max && c.max !== c.min) s += `-${c.max}`;
+    if (c.period) s += `/${c.period}`;
+    if (c.bonus) s += ` + ${c.bonus} bonus`;
+    if (c.equity) s += ` + ${c.equity} equity`;
+    return s;
+}
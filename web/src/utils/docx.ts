@@ -1,6 +1,7 @@
 import { Document, Packer, Paragraph, TextRun, HeadingLevel, AlignmentType, BorderStyle } from 'docx';
 import type { CVData, Education, Experience, Certification } from '../types';
 import { defaultLabels } from '../types';
+import { skillName } from './skills';
 
 export const generateDOCX = async (data: CVData): Promise<Blob> => {
     const p = data.personal;
@@ -100,7 +101,7 @@ export const generateDOCX = async (data: CVData): Promise<Blob> => {
                             text: sg.category + ": ",
                             bold: true,
                         }),
-                        new TextRun(sg.items.join(', ')),
+                        new TextRun(sg.items.map(skillName).join(', ')),
                     ],
                     bullet: {
                         level: 0,
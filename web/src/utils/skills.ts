@@ -0,0 +1,6 @@
+import type { Skill } from '../types';
+
+// Name of a skill, whether it was sent as a plain string or with metadata.
+export function skillName(s: Skill): string {
+    return typeof s === 'string' ? s : s.name;
+}